@@ -0,0 +1,285 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrNegativeCycle is returned when the graph has a cycle of negative
+// total weight, so that some shortest paths are not defined
+var ErrNegativeCycle = errors.New("graph has a negative cycle")
+
+// Unreachable is the distance in a DistanceMatrix between
+// nodes with no path from one to the other
+const Unreachable = math.MaxInt
+
+// DistanceMatrix holds the cost of the shortest path between
+// every ordered pair of nodes of a graph
+type DistanceMatrix struct {
+	// The node values, in ascending order, that
+	// index the rows and columns of the matrix
+	values []int
+	index  map[int]int
+
+	dist [][]int
+}
+
+// newDistanceMatrix returns a matrix over the nodes of g with every
+// node at distance 0 from itself and unreachable from the others
+func (g *ItemGraph) newDistanceMatrix() *DistanceMatrix {
+	m := &DistanceMatrix{
+		values: make([]int, len(g.nodes)),
+		index:  make(map[int]int, len(g.nodes)),
+		dist:   make([][]int, len(g.nodes)),
+	}
+	for i, n := range g.nodes {
+		m.values[i] = n.value
+	}
+	sort.Ints(m.values)
+	for i, v := range m.values {
+		m.index[v] = i
+		m.dist[i] = make([]int, len(m.values))
+		for j := range m.dist[i] {
+			m.dist[i][j] = Unreachable
+		}
+		m.dist[i][i] = 0
+	}
+	return m
+}
+
+// Values returns the node values indexing the rows and
+// columns of the matrix, in ascending order
+func (m *DistanceMatrix) Values() []int {
+	return append([]int(nil), m.values...)
+}
+
+// Row returns the costs of the shortest paths from the i-th node to
+// every node, in the order of Values. Unreachable nodes are at
+// distance Unreachable. The row must not be modified
+func (m *DistanceMatrix) Row(i int) []int {
+	return m.dist[i]
+}
+
+// Distance returns the cost of the shortest path from the node with
+// value u to the node with value v, and whether there is such a path
+func (m *DistanceMatrix) Distance(u, v int) (int, bool) {
+	i, ok1 := m.index[u]
+	j, ok2 := m.index[v]
+	if !ok1 || !ok2 || m.dist[i][j] == Unreachable {
+		return 0, false
+	}
+	return m.dist[i][j], true
+}
+
+// FloydWarshall finds the shortest paths between every pair of nodes
+// in O(V^3) time. Negative edge weights are allowed, but it returns
+// ErrNegativeCycle if the graph has a negative cycle
+func (g *ItemGraph) FloydWarshall() (*DistanceMatrix, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	m := g.newDistanceMatrix()
+	for _, n := range g.nodes {
+		i := m.index[n.value]
+		for _, e := range g.edges[*n] {
+			j := m.index[e.Node.value]
+			if e.Weight < m.dist[i][j] {
+				m.dist[i][j] = e.Weight
+			}
+		}
+	}
+
+	d := m.dist
+	for k := range d {
+		dk := d[k]
+		for i := range d {
+			dik := d[i][k]
+			if dik == Unreachable {
+				continue
+			}
+			di := d[i]
+			for j, dkj := range dk {
+				if dkj != Unreachable && dik+dkj < di[j] {
+					di[j] = dik + dkj
+				}
+			}
+		}
+	}
+
+	for i := range d {
+		if d[i][i] < 0 {
+			return nil, ErrNegativeCycle
+		}
+	}
+	return m, nil
+}
+
+// Johnson finds the shortest paths between every pair of nodes in
+// O(VE log V) time, which beats FloydWarshall on sparse graphs.
+// Negative edge weights are allowed, but it returns ErrNegativeCycle
+// if the graph has a negative cycle
+func (g *ItemGraph) Johnson() (*DistanceMatrix, error) {
+	js, err := g.JohnsonSearch()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &DistanceMatrix{
+		values: js.values,
+		index:  make(map[int]int, len(js.values)),
+		dist:   make([][]int, len(js.values)),
+	}
+	for i, v := range m.values {
+		m.index[v] = i
+		m.dist[i] = make([]int, len(m.values))
+		js.Row(i, m.dist[i])
+	}
+	return m, nil
+}
+
+// JohnsonSearch finds the shortest paths from one node at a time with
+// Johnson's algorithm, so that the rows of the distance matrix of a
+// large graph can be used without holding all of them. It is built
+// from a snapshot of the graph, which may change afterward
+type JohnsonSearch struct {
+	// The node values, in ascending order, that
+	// number the nodes of the search
+	values []int
+
+	// The potential of each node, and the edges leaving
+	// it reweighted to be non-negative
+	hs  []int
+	adj [][]arc
+}
+
+// JohnsonSearch runs the Bellman-Ford step of Johnson's algorithm, and
+// returns the search that finds the shortest paths from each node. It
+// returns ErrNegativeCycle if the graph has a negative cycle
+func (g *ItemGraph) JohnsonSearch() (*JohnsonSearch, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	h, err := g.potentials()
+	if err != nil {
+		return nil, err
+	}
+
+	// Number the nodes and reweight the edges once, so that each
+	// search works on slices
+	js := &JohnsonSearch{values: make([]int, len(g.nodes))}
+	for i, n := range g.nodes {
+		js.values[i] = n.value
+	}
+	sort.Ints(js.values)
+	index := make(map[int]int, len(js.values))
+	for i, v := range js.values {
+		index[v] = i
+	}
+	js.hs = make([]int, len(js.values))
+	js.adj = make([][]arc, len(js.values))
+	for _, n := range g.nodes {
+		i := index[n.value]
+		js.hs[i] = h[n.value]
+		for _, e := range g.edges[*n] {
+			w := e.Weight + h[n.value] - h[e.Node.value]
+			js.adj[i] = append(js.adj[i], arc{to: index[e.Node.value], weight: w})
+		}
+	}
+	return js, nil
+}
+
+// Values returns the node values numbering the rows and
+// columns of the search, in ascending order
+func (js *JohnsonSearch) Values() []int {
+	return append([]int(nil), js.values...)
+}
+
+// Row fills dist, which must hold a number for each node, with the costs
+// of the shortest paths from the i-th node to every node, in the order
+// of Values. Unreachable nodes are at distance Unreachable
+func (js *JohnsonSearch) Row(i int, dist []int) {
+	for j := range dist {
+		dist[j] = Unreachable
+	}
+	reweightedDistances(js.adj, i, dist)
+	for j, d := range dist {
+		// Undo the reweighting of the path
+		if d != Unreachable {
+			dist[j] = d - js.hs[i] + js.hs[j]
+		}
+	}
+}
+
+// arc is an edge to the node numbered to
+type arc struct {
+	to, weight int
+}
+
+// potentials runs the Bellman-Ford algorithm from a virtual node with
+// an edge of weight 0 to every node. The resulting distances h make
+// every edge weight w(u, v) + h(u) - h(v) non-negative
+func (g *ItemGraph) potentials() (map[int]int, error) {
+	h := make(map[int]int, len(g.nodes))
+	for _, n := range g.nodes {
+		h[n.value] = 0
+	}
+
+	// Distances settle within V rounds, counting the virtual node,
+	// unless there is a negative cycle
+	for round := 0; round <= len(g.nodes); round++ {
+		changed := false
+		for _, n := range g.nodes {
+			for _, e := range g.edges[*n] {
+				if d := h[n.value] + e.Weight; d < h[e.Node.value] {
+					h[e.Node.value] = d
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			return h, nil
+		}
+	}
+	return nil, ErrNegativeCycle
+}
+
+// reweightedDistances runs Dijkstra's algorithm from the node numbered
+// source over the non-negative edges adj, and fills dist with the
+// distances found
+func reweightedDistances(adj [][]arc, source int, dist []int) {
+	settled := make([]bool, len(adj))
+	pq := &arcQueue{{to: source, weight: 0}}
+	dist[source] = 0
+
+	for pq.Len() > 0 {
+		v := heap.Pop(pq).(arc)
+		if settled[v.to] {
+			continue
+		}
+		settled[v.to] = true
+		for _, e := range adj[v.to] {
+			if d := v.weight + e.weight; !settled[e.to] && d < dist[e.to] {
+				dist[e.to] = d
+				heap.Push(pq, arc{to: e.to, weight: d})
+			}
+		}
+	}
+}
+
+// arcQueue is a heap of nodes by tentative distance, held as arcs from
+// the source. A node may be pushed again with a lower distance, and its
+// stale entries are skipped when popped
+type arcQueue []arc
+
+func (q arcQueue) Len() int            { return len(q) }
+func (q arcQueue) Less(i, j int) bool  { return q[i].weight < q[j].weight }
+func (q arcQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *arcQueue) Push(x interface{}) { *q = append(*q, x.(arc)) }
+func (q *arcQueue) Pop() interface{} {
+	old := *q
+	x := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkMatrix compares every distance of m with a Dijkstra search
+// from each node of g
+func checkMatrix(t *testing.T, name string, g *ItemGraph, m *DistanceMatrix) {
+	for _, n := range g.Nodes() {
+		tree := g.ShortestPathTree(n)
+		for _, u := range g.Nodes() {
+			want, reachable := tree.Distance(u.Value())
+			got, ok := m.Distance(n.Value(), u.Value())
+			if ok != reachable || got != want {
+				t.Fatal(name, ": distance from", n, "to", u, ": expected", want, reachable, "received", got, ok)
+			}
+		}
+	}
+}
+
+// Test that both algorithms agree with Dijkstra's algorithm
+func TestItemGraph_AllPairs(t *testing.T) {
+	g, _ := randomGraph(60)
+	g.AddNode(NewNode(-1))
+
+	fw, err := g.FloydWarshall()
+	if err != nil {
+		t.Fatal("FloydWarshall:", err)
+	}
+	checkMatrix(t, "FloydWarshall", g, fw)
+
+	j, err := g.Johnson()
+	if err != nil {
+		t.Fatal("Johnson:", err)
+	}
+	checkMatrix(t, "Johnson", g, j)
+
+	values := fw.Values()
+	if len(values) != 61 || values[0] != -1 || values[60] != 59 {
+		t.Error("values: expected -1 to 59, received", values)
+	}
+	if row := fw.Row(0); row[0] != 0 || row[1] != Unreachable {
+		t.Error("row of -1: expected 0 then", Unreachable, "received", row[:2])
+	}
+}
+
+// Test negative edge weights, with and without a negative cycle
+func TestItemGraph_AllPairsNegative(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	g := NewDirectedGraph()
+	nodes := make([]*Node, 30)
+	for i := range nodes {
+		nodes[i] = NewNode(i)
+		g.AddNode(nodes[i])
+	}
+
+	// Edges only go from lower to higher values, so there
+	// is no cycle to be negative
+	for i := range nodes {
+		for k := 0; k < 3; k++ {
+			if j := i + 1 + r.Intn(5); j < len(nodes) {
+				g.AddWeightedEdge(nodes[i], nodes[j], r.Intn(20)-8)
+			}
+		}
+	}
+
+	fw, err1 := g.FloydWarshall()
+	j, err2 := g.Johnson()
+	if err1 != nil || err2 != nil {
+		t.Fatal("unexpected errors", err1, err2)
+	}
+	for _, u := range nodes {
+		for _, v := range nodes {
+			d1, ok1 := fw.Distance(u.Value(), v.Value())
+			d2, ok2 := j.Distance(u.Value(), v.Value())
+			if d1 != d2 || ok1 != ok2 {
+				t.Fatal("distance from", u, "to", v, ": FloydWarshall", d1, ok1, "Johnson", d2, ok2)
+			}
+		}
+	}
+
+	// Close a negative cycle
+	g.AddWeightedEdge(nodes[29], nodes[0], -1000)
+	if _, err := g.FloydWarshall(); err != ErrNegativeCycle {
+		t.Error("FloydWarshall: expected", ErrNegativeCycle, "received", err)
+	}
+	if _, err := g.Johnson(); err != ErrNegativeCycle {
+		t.Error("Johnson: expected", ErrNegativeCycle, "received", err)
+	}
+
+	// In an undirected graph, a negative edge is a negative cycle
+	u := NewGraph()
+	a, b := NewNode(1), NewNode(2)
+	u.AddNode(a)
+	u.AddNode(b)
+	u.AddWeightedEdge(a, b, -1)
+	if _, err := u.Johnson(); err != ErrNegativeCycle {
+		t.Error("undirected Johnson: expected", ErrNegativeCycle, "received", err)
+	}
+}
+
+func BenchmarkItemGraph_FloydWarshall(b *testing.B) {
+	g, _ := randomGraph(300)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.FloydWarshall()
+	}
+}
+
+func BenchmarkItemGraph_Johnson(b *testing.B) {
+	g, _ := randomGraph(300)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Johnson()
+	}
+}
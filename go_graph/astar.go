@@ -0,0 +1,161 @@
+package graph
+
+import "math"
+
+// Heuristic estimates the cost of the cheapest path between two nodes.
+// AStar finds shortest paths as long as the estimate never exceeds the
+// actual cost
+type Heuristic interface {
+	Estimate(from, to *Node) int
+}
+
+// HeuristicFunc adapts a function to the Heuristic interface
+type HeuristicFunc func(from, to *Node) int
+
+// Estimate calls f(from, to)
+func (f HeuristicFunc) Estimate(from, to *Node) int {
+	return f(from, to)
+}
+
+// The mean radius of the Earth, in meters
+const earthRadius = 6371008.8
+
+// EuclideanDistance is the straight-line distance between p and q
+func EuclideanDistance(p, q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+// HaversineDistance is the great-circle distance in meters between
+// p and q, taken as longitude and latitude in degrees
+func HaversineDistance(p, q Point) float64 {
+	rad := math.Pi / 180
+	dLat := (q.Y - p.Y) * rad
+	dLon := (q.X - p.X) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(p.Y*rad)*math.Cos(q.Y*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Euclidean estimates costs as Scale times the straight-line
+// distance between the positions of the nodes
+type Euclidean struct {
+	Scale float64
+}
+
+// Estimate implements Heuristic. It is 0 if either node has no position
+func (h Euclidean) Estimate(from, to *Node) int {
+	return estimate(from, to, h.Scale, EuclideanDistance)
+}
+
+// Haversine estimates costs as Scale times the great-circle distance
+// in meters between the positions of the nodes, taken as longitude
+// and latitude in degrees
+type Haversine struct {
+	Scale float64
+}
+
+// Estimate implements Heuristic. It is 0 if either node has no position
+func (h Haversine) Estimate(from, to *Node) int {
+	return estimate(from, to, h.Scale, HaversineDistance)
+}
+
+// estimate rounds down scale times the distance between the positions
+// of from and to, so that an exact lower bound stays one
+func estimate(from, to *Node, scale float64, distance func(p, q Point) float64) int {
+	if from.pos == nil || to.pos == nil {
+		return 0
+	}
+	return int(math.Floor(scale * distance(*from.pos, *to.pos)))
+}
+
+// CostPerDistance returns the lowest ratio of weight to distance over
+// the edges of the graph. Used as the Scale of a heuristic measuring
+// the same distance, it never overestimates the cost of a path. It is
+// 0 if some edge has an end without a position, since a path through
+// it is not bounded by the distance, or if some edge has a weight of
+// 0 or less
+func (g *ItemGraph) CostPerDistance(distance func(p, q Point) float64) float64 {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	scale := math.Inf(1)
+	for _, n := range g.nodes {
+		for _, e := range g.edges[*n] {
+			if n.pos == nil || e.Node.pos == nil {
+				return 0
+			}
+			d := distance(*n.pos, *e.Node.pos)
+			if d == 0 {
+				continue
+			}
+			if e.Weight <= 0 {
+				return 0
+			}
+			if r := float64(e.Weight) / d; r < scale {
+				scale = r
+			}
+		}
+	}
+	if math.IsInf(scale, 1) {
+		return 0
+	}
+	return scale
+}
+
+// AStar finds the shortest path from start to end, along with its
+// cost, searching first the nodes that h estimates to be on the
+// cheapest paths. It also returns the number of nodes it took off its
+// queue. It returns ErrNoPath if end cannot be reached. Edge weights
+// must be non-negative and h must never overestimate
+func (g *ItemGraph) AStar(start, end *Node, h Heuristic) ([]int, int, int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.aStar(start, end, h)
+}
+
+func (g *ItemGraph) aStar(start, end *Node, h Heuristic) ([]int, int, int, error) {
+	// The cost of the best path found so far to each node, and
+	// the node before it on that path
+	best := map[int]int{start.value: 0}
+	prev := make(map[int]int)
+
+	pq := NewNodeQueue()
+	pq.Enqueue(Vertex{Node: start, Distance: h.Estimate(start, end)})
+	settled := 0
+	for !pq.IsEmpty() {
+		v := pq.Dequeue()
+		settled++
+		if v.Node.value == end.value {
+			return tracePath(prev, start.value, end.value), best[end.value], settled, nil
+		}
+
+		for _, e := range g.edges[*v.Node] {
+			u := e.Node.value
+			d := best[v.Node.value] + e.Weight
+			if b, ok := best[u]; ok && d >= b {
+				continue
+			}
+			// A node is searched again whenever a cheaper path
+			// to it turns up, so that a heuristic that is not
+			// consistent still finds the shortest path
+			best[u] = d
+			prev[u] = v.Node.value
+			pq.Enqueue(Vertex{Node: e.Node, Distance: d + h.Estimate(e.Node, end)})
+		}
+	}
+	return nil, 0, settled, ErrNoPath
+}
+
+// tracePath walks the predecessors in prev back from the node with
+// value dst to the node with value src, and returns the path between
+func tracePath(prev map[int]int, src, dst int) []int {
+	path := []int{dst}
+	for n := dst; n != src; {
+		n = prev[n]
+		path = append(path, n)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
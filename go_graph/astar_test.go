@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// gridGraph returns an undirected n by n grid of nodes placed at their
+// coordinates, where each edge weighs 10 to 19 times its length
+func gridGraph(n int, seed int64) *ItemGraph {
+	r := rand.New(rand.NewSource(seed))
+	g := NewGraph()
+	nodes := make([]*Node, n*n)
+	for i := range nodes {
+		nodes[i] = NewNodeAt(i, Point{X: float64(i % n), Y: float64(i / n)})
+		g.AddNode(nodes[i])
+	}
+	for i := range nodes {
+		if i%n < n-1 {
+			g.AddWeightedEdge(nodes[i], nodes[i+1], 10+r.Intn(10))
+		}
+		if i+n < len(nodes) {
+			g.AddWeightedEdge(nodes[i], nodes[i+n], 10+r.Intn(10))
+		}
+	}
+	return g
+}
+
+// Test that A* finds paths as cheap as Dijkstra's algorithm, with
+// the built-in heuristic, one that is not consistent, and none
+func TestItemGraph_AStar(t *testing.T) {
+	g := gridGraph(20, 1)
+	nodes := g.Nodes()
+
+	scale := g.CostPerDistance(EuclideanDistance)
+	if scale != 10 {
+		t.Error("cost per distance: expected", 10, "received", scale)
+	}
+
+	// Admissible, but far too low away from the target
+	jumpy := HeuristicFunc(func(from, to *Node) int {
+		if from.value%7 == 0 {
+			return 0
+		}
+		return Euclidean{Scale: scale}.Estimate(from, to)
+	})
+	heuristics := map[string]Heuristic{
+		"euclidean": Euclidean{Scale: scale},
+		"jumpy":     jumpy,
+		"zero":      Euclidean{},
+	}
+
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 20; i++ {
+		s, e := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+		_, want, _ := g.ShortestPathTree(s).PathTo(e.Value())
+		for name, h := range heuristics {
+			path, cost, _, err := g.AStar(s, e, h)
+			if err != nil || cost != want {
+				t.Fatal(name, ": path from", s, "to", e, ": expected cost", want, "received", cost, err)
+			}
+			if path[0] != s.Value() || path[len(path)-1] != e.Value() || g.pathCost(path) != cost {
+				t.Fatal(name, ": path from", s, "to", e, "costs", cost, "received", path)
+			}
+		}
+	}
+
+	// Nodes without a position are estimated at 0
+	lone := NewNode(-1)
+	g.AddNode(lone)
+	if _, _, _, err := g.AStar(nodes[0], lone, Euclidean{Scale: scale}); err != ErrNoPath {
+		t.Error("unreachable node: expected", ErrNoPath, "received", err)
+	}
+}
+
+// pathCost adds up the weights along the path through the given nodes
+func (g *ItemGraph) pathCost(path []int) int {
+	costs := g.prefixCosts(path)
+	return costs[len(costs)-1]
+}
+
+// Test that a cheap path through a node without a position is not
+// overestimated, however far apart its ends are
+func TestItemGraph_CostPerDistanceUnplaced(t *testing.T) {
+	g := NewGraph()
+	a := NewNodeAt(1, Point{X: 0, Y: 0})
+	b := NewNodeAt(2, Point{X: 10, Y: 0})
+	u := NewNode(3)
+	g.AddNode(a)
+	g.AddNode(b)
+	g.AddNode(u)
+	g.AddWeightedEdge(a, b, 10)
+	g.AddWeightedEdge(a, u, 1)
+	g.AddWeightedEdge(u, b, 2)
+
+	scale := g.CostPerDistance(EuclideanDistance)
+	if scale != 0 {
+		t.Error("cost per distance: expected", 0, "received", scale)
+	}
+	path, cost, _, err := g.AStar(a, b, Euclidean{Scale: scale})
+	if err != nil || cost != 3 || !equal(path, []int{1, 3, 2}) {
+		t.Error("path through an unplaced node: expected", []int{1, 3, 2}, "with cost", 3, "received", path, "with cost", cost, err)
+	}
+}
+
+func TestHaversineDistance(t *testing.T) {
+	london := Point{X: -0.1278, Y: 51.5074}
+	paris := Point{X: 2.3522, Y: 48.8566}
+	if d := HaversineDistance(london, paris); math.Abs(d-343.5e3) > 1e3 {
+		t.Error("London to Paris: expected about", 343.5e3, "meters, received", d)
+	}
+	if d := HaversineDistance(paris, paris); d != 0 {
+		t.Error("Paris to Paris: expected", 0, "received", d)
+	}
+
+	a, b := NewNodeAt(1, london), NewNodeAt(2, paris)
+	if e := (Haversine{Scale: 0.001}).Estimate(a, b); e != 343 {
+		t.Error("estimate in km: expected", 343, "received", e)
+	}
+}
+
+func BenchmarkItemGraph_AStar(b *testing.B) {
+	g := gridGraph(100, 1)
+	nodes := g.Nodes()
+	h := Euclidean{Scale: g.CostPerDistance(EuclideanDistance)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.AStar(nodes[5050], nodes[5090], h)
+	}
+}
+
+func BenchmarkItemGraph_AStarDijkstra(b *testing.B) {
+	g := gridGraph(100, 1)
+	nodes := g.Nodes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.AStar(nodes[5050], nodes[5090], Euclidean{})
+	}
+}
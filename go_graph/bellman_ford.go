@@ -0,0 +1,90 @@
+package graph
+
+import "fmt"
+
+// NegativeCycleError is returned when a cycle of negative total weight
+// can be reached from the source of a search. It matches
+// ErrNegativeCycle with errors.Is
+type NegativeCycleError struct {
+	// The values of the nodes around the cycle, in the direction
+	// of its edges. The first node is not repeated at the end
+	Cycle []int
+}
+
+func (e *NegativeCycleError) Error() string {
+	return fmt.Sprintf("negative cycle through %v", e.Cycle)
+}
+
+func (e *NegativeCycleError) Is(target error) bool {
+	return target == ErrNegativeCycle
+}
+
+// HasNegativeWeights tells whether any edge of the graph has a
+// negative weight, which Dijkstra's algorithm cannot handle
+func (g *ItemGraph) HasNegativeWeights() bool {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.negEdges > 0
+}
+
+// BellmanFord finds the shortest paths from source to every node
+// reachable from it in O(VE) time. Negative edge weights are allowed,
+// but if a negative cycle can be reached from source, it returns a
+// *NegativeCycleError holding the cycle. In an undirected graph, an
+// edge of negative weight is such a cycle
+func (g *ItemGraph) BellmanFord(source *Node) (*PathTree, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	t := &PathTree{
+		source: source.value,
+		dist:   map[int]int{source.value: 0},
+		prev:   make(map[int]int),
+	}
+
+	// Shortest paths have fewer than V edges, so distances settle
+	// within V-1 rounds unless there is a negative cycle
+	last := source.value
+	for round := 0; round < len(g.nodes); round++ {
+		changed := false
+		for _, n := range g.nodes {
+			d, ok := t.dist[n.value]
+			if !ok {
+				continue
+			}
+			for _, e := range g.edges[*n] {
+				u := e.Node.value
+				if old, ok := t.dist[u]; !ok || d+e.Weight < old {
+					t.dist[u] = d + e.Weight
+					t.prev[u] = n.value
+					last = u
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			return t, nil
+		}
+	}
+	return nil, &NegativeCycleError{Cycle: cycleThrough(t.prev, last, len(g.nodes))}
+}
+
+// cycleThrough finds the cycle of the predecessors prev that is reached
+// by walking back from the node with value v, in a graph of n nodes
+func cycleThrough(prev map[int]int, v int, n int) []int {
+	// After n steps back the walk has entered the cycle
+	for i := 0; i < n; i++ {
+		v = prev[v]
+	}
+
+	cycle := []int{v}
+	for u := prev[v]; u != v; u = prev[u] {
+		cycle = append(cycle, u)
+	}
+
+	// The walk went against the edges
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return cycle
+}
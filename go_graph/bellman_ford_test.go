@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// Test that BellmanFord agrees with Johnson's algorithm on a
+// graph with negative weights but no negative cycle
+func TestItemGraph_BellmanFord(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	g := NewDirectedGraph()
+	nodes := make([]*Node, 40)
+	for i := range nodes {
+		nodes[i] = NewNode(i)
+		g.AddNode(nodes[i])
+	}
+
+	// Negative edges only go from lower to higher values,
+	// and the edges back are heavy enough to leave no
+	// negative cycle
+	for i := range nodes {
+		for k := 0; k < 3; k++ {
+			if j := i + 1 + r.Intn(6); j < len(nodes) {
+				g.AddWeightedEdge(nodes[i], nodes[j], r.Intn(20)-8)
+			}
+		}
+		if r.Intn(4) == 0 {
+			g.AddWeightedEdge(nodes[i], nodes[r.Intn(i+1)], 1000)
+		}
+	}
+
+	m, err := g.Johnson()
+	if err != nil {
+		t.Fatal("Johnson:", err)
+	}
+	for _, s := range nodes {
+		tree, err := g.BellmanFord(s)
+		if err != nil {
+			t.Fatal("BellmanFord from", s, ":", err)
+		}
+		for _, u := range nodes {
+			want, reachable := m.Distance(s.Value(), u.Value())
+			got, ok := tree.Distance(u.Value())
+			if ok != reachable || got != want {
+				t.Fatal("distance from", s, "to", u, ": expected", want, reachable, "received", got, ok)
+			}
+			if path, cost, err := tree.PathTo(u.Value()); err == nil && g.pathCost(path) != cost {
+				t.Fatal("path from", s, "to", u, "costs", cost, "received", path)
+			}
+		}
+	}
+}
+
+// Test that the reported cycle is a negative cycle of the graph,
+// and that cycles out of reach of the source are ignored
+func TestItemGraph_BellmanFordNegativeCycle(t *testing.T) {
+	g := newTestGraph(true, 6, []EdgeSpec{
+		{1, 2, 1}, {2, 3, 2}, {3, 4, 1}, {4, 2, -4}, {4, 5, 1},
+		{6, 1, 1},
+	})
+	n := func(v int) *Node {
+		node, _ := g.FindNode(v)
+		return node
+	}
+
+	_, err := g.BellmanFord(n(1))
+	var cycle *NegativeCycleError
+	if !errors.As(err, &cycle) || !errors.Is(err, ErrNegativeCycle) {
+		t.Fatal("error: expected a negative cycle, received", err)
+	}
+	if len(cycle.Cycle) != 3 || g.pathCost(append(cycle.Cycle, cycle.Cycle[0])) != -1 {
+		t.Error("cycle: expected 2, 3, 4 in some rotation, received", cycle.Cycle)
+	}
+
+	// 5 reaches no cycle, and 6 is only reached from the cycle
+	if tree, err := g.BellmanFord(n(5)); err != nil || tree.Len() != 1 {
+		t.Error("from 5: expected a tree of 1 node, received", tree, err)
+	}
+
+	// In an undirected graph, a negative edge is a negative cycle
+	u := newTestGraph(false, 3, []EdgeSpec{{1, 2, 3}, {2, 3, -1}})
+	start, _ := u.FindNode(1)
+	if _, err := u.BellmanFord(start); !errors.As(err, &cycle) || len(cycle.Cycle) != 2 {
+		t.Error("undirected: expected a cycle of 2 nodes, received", err)
+	}
+}
+
+// Test that HasNegativeWeights follows the edges being added,
+// reweighted, and removed
+func TestItemGraph_HasNegativeWeights(t *testing.T) {
+	g := newTestGraph(false, 3, []EdgeSpec{{1, 2, 1}})
+	n1, _ := g.FindNode(1)
+	n2, _ := g.FindNode(2)
+	n3, _ := g.FindNode(3)
+
+	steps := []struct {
+		name   string
+		change func()
+		want   bool
+	}{
+		{"non-negative", func() {}, false},
+		{"negative edge", func() { g.AddWeightedEdge(n2, n3, -1) }, true},
+		{"reweighted", func() { g.AddWeightedEdge(n2, n3, 2) }, false},
+		{"reweighted back", func() { g.AddWeightedEdge(n3, n2, -2) }, true},
+		{"edge removed", func() { g.RemoveEdge(n2, n3) }, false},
+		{"negative again", func() { g.AddWeightedEdge(n1, n3, -5) }, true},
+		{"node removed", func() { g.RemoveNode(n3) }, false},
+	}
+	for _, s := range steps {
+		s.change()
+		if got := g.HasNegativeWeights(); got != s.want {
+			t.Error(s.name, ": expected", s.want, "received", got)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package graph
+
+// BidirectionalDijkstra finds the shortest path from start to end, along
+// with its cost, by running Dijkstra's algorithm forward from start and
+// backward from end at once, until the two searches meet. It also
+// returns the number of nodes the two searches settled. It returns
+// ErrNoPath if end cannot be reached. Edge weights must be non-negative
+func (g *ItemGraph) BidirectionalDijkstra(start, end *Node) ([]int, int, int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.bidirectionalDijkstra(start, end)
+}
+
+func (g *ItemGraph) bidirectionalDijkstra(start, end *Node) ([]int, int, int, error) {
+	if start.value == end.value {
+		return []int{start.value}, 0, 0, nil
+	}
+
+	backward := g.edges
+	if g.directed {
+		backward = g.inEdges
+	}
+	fwd := newHalfSearch(g.edges, start)
+	bwd := newHalfSearch(backward, end)
+
+	// The cost of the shortest path found so far through the
+	// node where the searches met, or -1 before they meet
+	cost, meet := -1, 0
+
+	for !fwd.pq.IsEmpty() && !bwd.pq.IsEmpty() {
+		// Any path not found yet leaves both frontiers, so it
+		// costs at least the sum of their nearest nodes
+		if cost >= 0 && fwd.pq.min()+bwd.pq.min() >= cost {
+			break
+		}
+
+		// Grow the smaller frontier
+		s, other := fwd, bwd
+		if bwd.pq.Size() < fwd.pq.Size() {
+			s, other = bwd, fwd
+		}
+		for _, u := range s.step() {
+			if d, ok := other.best[u]; ok && (cost < 0 || s.best[u]+d < cost) {
+				cost, meet = s.best[u]+d, u
+			}
+		}
+	}
+	settled := len(fwd.settled) + len(bwd.settled)
+	if cost < 0 {
+		return nil, 0, settled, ErrNoPath
+	}
+
+	// Join the path from start to the meeting node with
+	// the path from there to end
+	path := tracePath(fwd.prev, start.value, meet)
+	for v := meet; v != end.value; {
+		v = bwd.prev[v]
+		path = append(path, v)
+	}
+	return path, cost, settled, nil
+}
+
+// halfSearch is one direction of a bidirectional search
+type halfSearch struct {
+	// The edges followed, leaving each node in the
+	// direction of the search
+	edges map[Node][]*Edge
+
+	// The best distance found so far from the node the search
+	// starts at, and the node before each one on that path
+	best map[int]int
+	prev map[int]int
+
+	settled map[int]bool
+	pq      *NodeQueue
+}
+
+func newHalfSearch(edges map[Node][]*Edge, source *Node) *halfSearch {
+	s := &halfSearch{
+		edges:   edges,
+		best:    map[int]int{source.value: 0},
+		prev:    make(map[int]int),
+		settled: make(map[int]bool),
+		pq:      NewNodeQueue(),
+	}
+	s.pq.Enqueue(Vertex{Node: source, Distance: 0})
+	return s
+}
+
+// step settles the nearest node in the queue and relaxes its edges.
+// It returns the values of the nodes whose distance it set, along
+// with the settled node itself
+func (s *halfSearch) step() []int {
+	v := s.pq.Dequeue()
+	s.settled[v.Node.value] = true
+
+	touched := []int{v.Node.value}
+	for _, e := range s.edges[*v.Node] {
+		u := e.Node.value
+		if s.settled[u] {
+			continue
+		}
+		d := v.Distance + e.Weight
+		if b, ok := s.best[u]; !ok || d < b {
+			s.best[u] = d
+			s.prev[u] = v.Node.value
+			s.pq.Enqueue(Vertex{Node: e.Node, Distance: d})
+			touched = append(touched, u)
+		}
+	}
+	return touched
+}
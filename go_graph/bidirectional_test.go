@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Test that the searches meet on a shortest path, in directed and
+// undirected graphs, with zero weights and unreachable nodes
+func TestItemGraph_BidirectionalDijkstra(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		r := rand.New(rand.NewSource(3))
+		g := NewGraph()
+		if directed {
+			g = NewDirectedGraph()
+		}
+		nodes := make([]*Node, 200)
+		for i := range nodes {
+			nodes[i] = NewNode(i)
+			g.AddNode(nodes[i])
+		}
+		for k := 0; k < 350; k++ {
+			g.AddWeightedEdge(nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))], r.Intn(20))
+		}
+
+		for i := 0; i < 300; i++ {
+			s, e := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+			_, want, wantErr := g.ShortestPathTree(s).PathTo(e.Value())
+			path, cost, settled, err := g.BidirectionalDijkstra(s, e)
+			if err != wantErr || cost != want {
+				t.Fatal("directed", directed, ": path from", s, "to", e, ": expected", want, wantErr, "received", cost, err)
+			}
+			// Each search settles a node at most once
+			if s != e && (settled < 1 || settled > 2*len(nodes)) {
+				t.Fatal("directed", directed, ": path from", s, "to", e, ": settled", settled, "nodes")
+			}
+			if err == nil && (path[0] != s.Value() || path[len(path)-1] != e.Value() || g.pathCost(path) != cost) {
+				t.Fatal("directed", directed, ": path from", s, "to", e, "costs", cost, "received", path)
+			}
+		}
+	}
+}
+
+// Point-to-point queries between random nodes of a graph with 100,000
+// nodes, with a full Dijkstra search from the source
+func BenchmarkItemGraph_ShortestPathTree(b *testing.B) {
+	g, nodes := randomGraph(100000)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s, e := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+		g.ShortestPathTree(s).PathTo(e.Value())
+	}
+}
+
+// The same queries with bidirectional Dijkstra
+func BenchmarkItemGraph_BidirectionalDijkstra(b *testing.B) {
+	g, nodes := randomGraph(100000)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s, e := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+		g.BidirectionalDijkstra(s, e)
+	}
+}
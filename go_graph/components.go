@@ -0,0 +1,152 @@
+package graph
+
+import "sort"
+
+// Components labels each node of a snapshot of a graph with the
+// component it belongs to, as found by ConnectedComponents or
+// StronglyConnectedComponents. It is safe for concurrent use
+type Components struct {
+	// The number of the component of each node, by value.
+	// Connected components are numbered by their lowest
+	// value, strongly connected ones in topological order
+	label map[int]int
+
+	// The values of the nodes of each component, in ascending order
+	members [][]int
+}
+
+// ConnectedComponents finds the connected components of the graph as
+// it is when called, by merging the ends of every edge in a union-find
+// forest, in nearly O(V + E) time. The components of a directed graph
+// are its weakly connected ones, ignoring the direction of the edges
+func (g *ItemGraph) ConnectedComponents() *Components {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	values := make([]int, len(g.nodes))
+	for i, n := range g.nodes {
+		values[i] = n.value
+	}
+	sort.Ints(values)
+	index := make(map[int]int, len(values))
+	for i, v := range values {
+		index[v] = i
+	}
+
+	uf := newUnionFind(len(values))
+	for _, n := range g.nodes {
+		for _, e := range g.edges[*n] {
+			uf.union(index[n.value], index[e.Node.value])
+		}
+	}
+
+	// Going up the values, each new root starts a component
+	c := &Components{label: make(map[int]int, len(values))}
+	number := make(map[int]int)
+	for i, v := range values {
+		root := uf.find(i)
+		k, ok := number[root]
+		if !ok {
+			k = len(c.members)
+			number[root] = k
+			c.members = append(c.members, nil)
+		}
+		c.label[v] = k
+		c.members[k] = append(c.members[k], v)
+	}
+	return c
+}
+
+// Count returns the number of components
+func (c *Components) Count() int {
+	return len(c.members)
+}
+
+// Label returns the number of the component of the node with value v,
+// and false if there was no such node
+func (c *Components) Label(v int) (int, bool) {
+	k, ok := c.label[v]
+	return k, ok
+}
+
+// Members returns the values of the nodes of the k-th component,
+// in ascending order. The slice must not be modified
+func (c *Components) Members(k int) []int {
+	return c.members[k]
+}
+
+// Connected tells whether the nodes with values u and v are in the
+// same component
+func (c *Components) Connected(u, v int) bool {
+	k1, ok1 := c.label[u]
+	k2, ok2 := c.label[v]
+	return ok1 && ok2 && k1 == k2
+}
+
+// Reachable tells whether there is a path from start to end, by a
+// breadth-first search from start that stops once it finds end
+func (g *ItemGraph) Reachable(start, end *Node) bool {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if start.value == end.value {
+		return true
+	}
+	seen := map[int]bool{start.value: true}
+	queue := []*Node{start}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, e := range g.edges[*n] {
+			if e.Node.value == end.value {
+				return true
+			}
+			if !seen[e.Node.value] {
+				seen[e.Node.value] = true
+				queue = append(queue, e.Node)
+			}
+		}
+	}
+	return false
+}
+
+// unionFind is a disjoint-set forest over the numbers 0 to n-1
+type unionFind struct {
+	parent []int
+	rank   []int
+}
+
+func newUnionFind(n int) *unionFind {
+	uf := &unionFind{parent: make([]int, n), rank: make([]int, n)}
+	for i := range uf.parent {
+		uf.parent[i] = i
+	}
+	return uf
+}
+
+// find returns the root of the tree holding x, halving the
+// path to it on the way
+func (uf *unionFind) find(x int) int {
+	for uf.parent[x] != x {
+		uf.parent[x] = uf.parent[uf.parent[x]]
+		x = uf.parent[x]
+	}
+	return x
+}
+
+// union merges the trees holding x and y, and tells whether
+// they were apart
+func (uf *unionFind) union(x, y int) bool {
+	x, y = uf.find(x), uf.find(y)
+	if x == y {
+		return false
+	}
+	if uf.rank[x] < uf.rank[y] {
+		x, y = y, x
+	}
+	uf.parent[y] = x
+	if uf.rank[x] == uf.rank[y] {
+		uf.rank[x]++
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Test the components of undirected and directed graphs, where
+// they ignore the direction of the edges
+func TestItemGraph_ConnectedComponents(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		g        *ItemGraph
+		members  [][]int
+		together [][2]int
+		apart    [][2]int
+	}{
+		{
+			"undirected",
+			newTestGraph(false, 7, []EdgeSpec{{1, 3, 1}, {3, 5, 1}, {2, 6, 1}}),
+			[][]int{{1, 3, 5}, {2, 6}, {4}, {7}},
+			[][2]int{{1, 5}, {6, 2}, {4, 4}},
+			[][2]int{{1, 2}, {4, 7}, {1, 8}},
+		},
+		{
+			"directed",
+			newTestGraph(true, 5, []EdgeSpec{{2, 1, 1}, {3, 1, 1}, {4, 5, 1}}),
+			[][]int{{1, 2, 3}, {4, 5}},
+			[][2]int{{2, 3}, {5, 4}},
+			[][2]int{{3, 4}},
+		},
+		{"empty", NewGraph(), nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.g.ConnectedComponents()
+			if c.Count() != len(tt.members) {
+				t.Fatal("count: expected", len(tt.members), "received", c.Count())
+			}
+			for k, want := range tt.members {
+				if got := c.Members(k); !equal(got, want) {
+					t.Error("component", k, ": expected", want, "received", got)
+				}
+				for _, v := range want {
+					if label, _ := c.Label(v); label != k {
+						t.Error("label of", v, ": expected", k, "received", label)
+					}
+				}
+			}
+			for _, p := range tt.together {
+				if !c.Connected(p[0], p[1]) {
+					t.Error(p[0], "and", p[1], ": expected connected")
+				}
+			}
+			for _, p := range tt.apart {
+				if c.Connected(p[0], p[1]) {
+					t.Error(p[0], "and", p[1], ": expected apart")
+				}
+			}
+		})
+	}
+}
+
+// Test that Reachable agrees with Dijkstra's algorithm, and follows
+// the direction of the edges
+func TestItemGraph_Reachable(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for _, directed := range []bool{false, true} {
+		g := NewGraph()
+		if directed {
+			g = NewDirectedGraph()
+		}
+		nodes := make([]*Node, 100)
+		for i := range nodes {
+			nodes[i] = NewNode(i)
+			g.AddNode(nodes[i])
+		}
+		for k := 0; k < 80; k++ {
+			g.AddEdge(nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))])
+		}
+		c := g.ConnectedComponents()
+
+		for _, s := range nodes {
+			tree := g.ShortestPathTree(s)
+			for _, e := range nodes {
+				want := tree.Reachable(e.Value())
+				if got := g.Reachable(s, e); got != want {
+					t.Fatal("directed", directed, ": from", s, "to", e, ": expected", want, "received", got)
+				}
+				if want && !c.Connected(s.Value(), e.Value()) {
+					t.Fatal("directed", directed, ":", s, "reaches", e, "in another component")
+				}
+				if !directed && c.Connected(s.Value(), e.Value()) != want {
+					t.Fatal("undirected: components of", s, "and", e, ": expected connected", want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package graph
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+)
+
+// ErrNegativeWeights is returned by the algorithms that need
+// every edge weight to be non-negative
+var ErrNegativeWeights = errors.New("graph has negative weights")
+
+// The most nodes a witness search settles before giving up, in which
+// case the shortcut it would have made useless is added. Searches that
+// only estimate the number of shortcuts give up sooner
+const (
+	witnessLimit         = 500
+	simulateWitnessLimit = 20
+)
+
+// ContractionHierarchy answers shortest path queries on a snapshot of a
+// graph much faster than a search of the graph itself. Each node is
+// ranked, and shortcut edges are added so that every shortest path
+// climbs to a node of highest rank and then goes down. A query only
+// searches up from both ends. It is safe for concurrent use
+type ContractionHierarchy struct {
+	values []int
+	index  map[int]int
+
+	// The edges leaving each node to nodes of higher rank, and
+	// the edges entering each node from nodes of higher rank,
+	// pointing back to where they come from. Both include the
+	// shortcuts
+	up   [][]arc
+	down [][]arc
+
+	// The node a shortcut skips over, by the numbers of its ends
+	via map[[2]int]int
+}
+
+// Shortcuts returns the number of shortcut edges in the hierarchy
+func (h *ContractionHierarchy) Shortcuts() int {
+	return len(h.via)
+}
+
+// ContractionHierarchy builds a contraction hierarchy of the graph as it
+// is when called, reporting to progress, if not nil, each time a node
+// is ranked. It stops with the error of ctx if ctx is done first, and
+// returns ErrNegativeWeights if any edge has a negative weight
+func (g *ItemGraph) ContractionHierarchy(ctx context.Context, progress func(done, total int)) (*ContractionHierarchy, error) {
+	g.lock.RLock()
+	if g.negEdges > 0 {
+		g.lock.RUnlock()
+		return nil, ErrNegativeWeights
+	}
+	c := g.newContractor()
+	g.lock.RUnlock()
+
+	if err := c.run(ctx, progress); err != nil {
+		return nil, err
+	}
+	return c.h, nil
+}
+
+// contractor ranks the nodes of a hierarchy one at a time, removing
+// each from a working copy of the graph
+type contractor struct {
+	h *ContractionHierarchy
+
+	// The edges left between the nodes not ranked yet, leaving and
+	// entering each node. There is at most one edge from a node to
+	// another, with the lowest weight
+	out [][]arc
+	in  [][]arc
+
+	contracted []bool
+
+	// The length of the longest chain of ranked neighbors leading
+	// up to each node, which keeps the hierarchy shallow
+	level []int
+
+	// The number of neighbors of each node already ranked, and
+	// the priority each node is queued with
+	deleted  []int
+	priority []int
+
+	// Scratch space of witness searches. A distance is only set
+	// when its stamp is the current search
+	dist    []int
+	stamp   []int
+	settled []int
+	search  int
+	pq      arcQueue
+}
+
+func (g *ItemGraph) newContractor() *contractor {
+	n := len(g.nodes)
+	c := &contractor{
+		h: &ContractionHierarchy{
+			values: make([]int, n),
+			index:  make(map[int]int, n),
+			up:     make([][]arc, n),
+			down:   make([][]arc, n),
+			via:    make(map[[2]int]int),
+		},
+		out:        make([][]arc, n),
+		in:         make([][]arc, n),
+		contracted: make([]bool, n),
+		level:      make([]int, n),
+		deleted:    make([]int, n),
+		priority:   make([]int, n),
+		dist:       make([]int, n),
+		stamp:      make([]int, n),
+		settled:    make([]int, n),
+	}
+	for i, v := range g.nodes {
+		c.h.values[i] = v.value
+		c.h.index[v.value] = i
+	}
+	for i, v := range g.nodes {
+		for _, e := range g.edges[*v] {
+			if j := c.h.index[e.Node.value]; j != i {
+				c.out[i] = append(c.out[i], arc{to: j, weight: e.Weight})
+				c.in[j] = append(c.in[j], arc{to: i, weight: e.Weight})
+			}
+		}
+	}
+	return c
+}
+
+// run ranks every node, next the one whose removal adds the fewest
+// shortcuts for the edges it removes, preferring nodes with few
+// ranked neighbors to spread the ranks out
+func (c *contractor) run(ctx context.Context, progress func(done, total int)) error {
+	n := len(c.out)
+	pq := make(arcQueue, 0, n)
+	for v := 0; v < n; v++ {
+		c.priority[v] = c.simulate(v)
+		pq = append(pq, arc{to: v, weight: c.priority[v]})
+	}
+	heap.Init(&pq)
+
+	for done := 0; pq.Len() > 0; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// A node is queued again whenever its priority changes,
+		// and its older entries are skipped
+		top := heap.Pop(&pq).(arc)
+		v := top.to
+		if c.contracted[v] || top.weight != c.priority[v] {
+			continue
+		}
+
+		c.contract(v)
+		neighbors := c.remove(v)
+		for _, x := range neighbors {
+			if c.level[v]+1 > c.level[x] {
+				c.level[x] = c.level[v] + 1
+			}
+			if p := c.simulate(x); p != c.priority[x] {
+				c.priority[x] = p
+				heap.Push(&pq, arc{to: x, weight: p})
+			}
+		}
+
+		done++
+		if progress != nil {
+			progress(done, n)
+		}
+	}
+	return nil
+}
+
+// simulate returns the priority of v: mostly the number of shortcuts
+// ranking it would add, less the edges it would remove, then its ranked
+// neighbors and its level
+func (c *contractor) simulate(v int) int {
+	return 2*(c.shortcuts(v, false)-len(c.in[v])-len(c.out[v])) + c.deleted[v] + c.level[v]
+}
+
+// contract adds a shortcut for each pair of edges through v that
+// makes a shortest path
+func (c *contractor) contract(v int) {
+	c.shortcuts(v, true)
+}
+
+// shortcuts counts the pairs of edges through v that make shortest
+// paths, and adds a shortcut for each if add is set
+func (c *contractor) shortcuts(v int, add bool) int {
+	longest := 0
+	for _, a := range c.out[v] {
+		if a.weight > longest {
+			longest = a.weight
+		}
+	}
+
+	limit := simulateWitnessLimit
+	if add {
+		limit = witnessLimit
+	}
+
+	count := 0
+	for _, a := range c.in[v] {
+		u := a.to
+		c.witness(u, v, a.weight+longest, limit)
+		for _, b := range c.out[v] {
+			if b.to == u {
+				continue
+			}
+			// A path around v as short as the one through
+			// it makes the shortcut useless
+			if c.stamp[b.to] == c.search && c.dist[b.to] <= a.weight+b.weight {
+				continue
+			}
+			count++
+			if add {
+				c.addShortcut(u, b.to, a.weight+b.weight, v)
+			}
+		}
+	}
+	return count
+}
+
+// witness searches from u around v, as far as maxCost or until it
+// settles limit nodes or every node v leads to, leaving the cost of
+// the paths found in dist
+func (c *contractor) witness(u, v, maxCost, limit int) {
+	c.search++
+	c.dist[u], c.stamp[u] = 0, c.search
+	c.pq = append(c.pq[:0], arc{to: u, weight: 0})
+
+	// Mark the nodes v leads to, the targets of the search,
+	// with a stamp no other node can have
+	left := 0
+	for _, a := range c.out[v] {
+		if a.to != u && c.settled[a.to] != -c.search {
+			c.settled[a.to] = -c.search
+			left++
+		}
+	}
+
+	for n := 0; len(c.pq) > 0 && n < limit && left > 0; n++ {
+		x := heap.Pop(&c.pq).(arc)
+		if c.settled[x.to] == c.search {
+			continue
+		}
+		if x.weight > maxCost {
+			break
+		}
+		if c.settled[x.to] == -c.search {
+			left--
+		}
+		c.settled[x.to] = c.search
+		for _, a := range c.out[x.to] {
+			y := a.to
+			if y == v || c.settled[y] == c.search {
+				continue
+			}
+			if d := x.weight + a.weight; c.stamp[y] != c.search || d < c.dist[y] {
+				c.dist[y], c.stamp[y] = d, c.search
+				heap.Push(&c.pq, arc{to: y, weight: d})
+			}
+		}
+	}
+}
+
+// addShortcut adds an edge from u to x of the given weight, standing
+// for the path through v, unless there is a lighter edge already
+func (c *contractor) addShortcut(u, x, weight, v int) {
+	if !setArc(c.out, u, x, weight) {
+		return
+	}
+	setArc(c.in, x, u, weight)
+	c.h.via[[2]int{u, x}] = v
+}
+
+// setArc makes the weight of the arc from u to x in arcs no more than
+// weight, adding the arc if needed. It tells whether the arc changed
+func setArc(arcs [][]arc, u, x, weight int) bool {
+	for i, a := range arcs[u] {
+		if a.to == x {
+			if a.weight <= weight {
+				return false
+			}
+			arcs[u][i].weight = weight
+			return true
+		}
+	}
+	arcs[u] = append(arcs[u], arc{to: x, weight: weight})
+	return true
+}
+
+// remove ranks v above the nodes ranked so far, moving its remaining
+// edges into the hierarchy. It returns the neighbors of v
+func (c *contractor) remove(v int) []int {
+	var neighbors []int
+	for _, a := range c.out[v] {
+		c.h.up[v] = append(c.h.up[v], a)
+		c.in[a.to] = dropArc(c.in[a.to], v)
+		c.deleted[a.to]++
+		neighbors = append(neighbors, a.to)
+	}
+	for _, a := range c.in[v] {
+		c.h.down[v] = append(c.h.down[v], a)
+		c.out[a.to] = dropArc(c.out[a.to], v)
+		c.deleted[a.to]++
+		neighbors = append(neighbors, a.to)
+	}
+	c.out[v], c.in[v] = nil, nil
+	c.contracted[v] = true
+	return neighbors
+}
+
+// dropArc removes the arc to v from arcs
+func dropArc(arcs []arc, v int) []arc {
+	for i, a := range arcs {
+		if a.to == v {
+			arcs[i] = arcs[len(arcs)-1]
+			return arcs[:len(arcs)-1]
+		}
+	}
+	return arcs
+}
+
+// ShortestPath finds the shortest path between the nodes with values
+// start and end, along with its cost, in the graph the hierarchy was
+// built from. It also returns the number of nodes its two searches
+// settled. It returns ErrNoPath if end cannot be reached, and a
+// *NodeNotFoundError if either node was not in the graph
+func (h *ContractionHierarchy) ShortestPath(start, end int) ([]int, int, int, error) {
+	s, ok := h.index[start]
+	if !ok {
+		return nil, 0, 0, &NodeNotFoundError{Value: start}
+	}
+	t, ok := h.index[end]
+	if !ok {
+		return nil, 0, 0, &NodeNotFoundError{Value: end}
+	}
+	if s == t {
+		return []int{start}, 0, 0, nil
+	}
+
+	fwd := newUpwardSearch(h.up, s)
+	bwd := newUpwardSearch(h.down, t)
+
+	// Unlike a plain bidirectional search, the searches cannot stop
+	// when they first meet, since the node of highest rank on the
+	// shortest path may be settled late. Each goes on until its
+	// nearest node is farther than the best path found
+	cost, meet := -1, 0
+	for {
+		fOpen := fwd.pq.Len() > 0 && (cost < 0 || (*fwd.pq)[0].weight < cost)
+		bOpen := bwd.pq.Len() > 0 && (cost < 0 || (*bwd.pq)[0].weight < cost)
+		if !fOpen && !bOpen {
+			break
+		}
+
+		s, other := fwd, bwd
+		if !fOpen || (bOpen && (*bwd.pq)[0].weight < (*fwd.pq)[0].weight) {
+			s, other = bwd, fwd
+		}
+		x, ok := s.step()
+		if !ok {
+			continue
+		}
+		if d, ok := other.dist[x]; ok && (cost < 0 || s.dist[x]+d < cost) {
+			cost, meet = s.dist[x]+d, x
+		}
+	}
+	settled := len(fwd.settled) + len(bwd.settled)
+	if cost < 0 {
+		return nil, 0, settled, ErrNoPath
+	}
+
+	// Climb from both ends to the meeting node, then expand
+	// the shortcuts along the way
+	var nodes []int
+	for x := meet; x != s; x = fwd.prev[x] {
+		nodes = append(nodes, x)
+	}
+	nodes = append(nodes, s)
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+	for x := meet; x != t; {
+		x = bwd.prev[x]
+		nodes = append(nodes, x)
+	}
+
+	path := []int{start}
+	for i := 1; i < len(nodes); i++ {
+		path = h.unpack(nodes[i-1], nodes[i], path)
+	}
+	return path, cost, settled, nil
+}
+
+// unpack appends to path the values of the nodes after the node
+// numbered a, up to the node numbered b, on the edge from a to b
+func (h *ContractionHierarchy) unpack(a, b int, path []int) []int {
+	if v, ok := h.via[[2]int{a, b}]; ok {
+		path = h.unpack(a, v, path)
+		return h.unpack(v, b, path)
+	}
+	return append(path, h.values[b])
+}
+
+// upwardSearch is one direction of a query of a ContractionHierarchy
+type upwardSearch struct {
+	arcs [][]arc
+
+	// The best distance found so far from the node the search
+	// starts at, and the node before each one on that path
+	dist map[int]int
+	prev map[int]int
+
+	settled map[int]bool
+	pq      *arcQueue
+}
+
+func newUpwardSearch(arcs [][]arc, source int) *upwardSearch {
+	return &upwardSearch{
+		arcs:    arcs,
+		dist:    map[int]int{source: 0},
+		prev:    make(map[int]int),
+		settled: make(map[int]bool),
+		pq:      &arcQueue{{to: source, weight: 0}},
+	}
+}
+
+// step settles the nearest node in the queue and relaxes its arcs.
+// It returns the node, or false if the entry on top was stale
+func (s *upwardSearch) step() (int, bool) {
+	x := heap.Pop(s.pq).(arc)
+	if s.settled[x.to] {
+		return 0, false
+	}
+	s.settled[x.to] = true
+	for _, a := range s.arcs[x.to] {
+		if d, ok := s.dist[a.to]; !ok || x.weight+a.weight < d {
+			s.dist[a.to] = x.weight + a.weight
+			s.prev[a.to] = x.to
+			heap.Push(s.pq, arc{to: a.to, weight: x.weight + a.weight})
+		}
+	}
+	return x.to, true
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+// Test that queries of the hierarchy find paths as cheap as Dijkstra's
+// algorithm, on directed and undirected graphs with zero weights
+func TestItemGraph_ContractionHierarchy(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		r := rand.New(rand.NewSource(5))
+		g := NewGraph()
+		if directed {
+			g = NewDirectedGraph()
+		}
+		nodes := make([]*Node, 300)
+		for i := range nodes {
+			nodes[i] = NewNode(i)
+			g.AddNode(nodes[i])
+		}
+		for k := 0; k < 700; k++ {
+			g.AddWeightedEdge(nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))], r.Intn(30))
+		}
+
+		steps := 0
+		h, err := g.ContractionHierarchy(context.Background(), func(done, total int) {
+			steps++
+			if done != steps || total != len(nodes) {
+				t.Fatal("progress: expected", steps, "of", len(nodes), "received", done, "of", total)
+			}
+		})
+		if err != nil {
+			t.Fatal("ContractionHierarchy:", err)
+		}
+		if steps != len(nodes) {
+			t.Error("progress: expected", len(nodes), "steps, received", steps)
+		}
+
+		for i := 0; i < 500; i++ {
+			s, e := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+			_, want, wantErr := g.ShortestPathTree(s).PathTo(e.Value())
+			path, cost, _, err := h.ShortestPath(s.Value(), e.Value())
+			if err != wantErr || cost != want {
+				t.Fatal("directed", directed, ": path from", s, "to", e, ": expected", want, wantErr, "received", cost, err)
+			}
+			if err == nil && (path[0] != s.Value() || path[len(path)-1] != e.Value() || g.pathCost(path) != cost) {
+				t.Fatal("directed", directed, ": path from", s, "to", e, "costs", cost, "received", path)
+			}
+		}
+	}
+}
+
+// Test that the hierarchy cannot be built with negative weights,
+// and that building it stops when its context is canceled
+func TestItemGraph_ContractionHierarchyErrors(t *testing.T) {
+	g := newTestGraph(true, 3, []EdgeSpec{{1, 2, 1}, {2, 3, -1}})
+	if _, err := g.ContractionHierarchy(context.Background(), nil); err != ErrNegativeWeights {
+		t.Error("negative weights: expected", ErrNegativeWeights, "received", err)
+	}
+
+	g, _ = randomGraph(100)
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := g.ContractionHierarchy(ctx, func(done, total int) {
+		if done == 10 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Error("canceled: expected", context.Canceled, "received", err)
+	}
+
+	h, _ := g.ContractionHierarchy(context.Background(), nil)
+	if _, _, _, err := h.ShortestPath(1, 1000); err == nil {
+		t.Error("missing node: expected an error")
+	}
+}
+
+// Point-to-point queries on a 100 by 100 grid, which is more like
+// a road network than randomGraph
+func BenchmarkContractionHierarchy_ShortestPath(b *testing.B) {
+	g := gridGraph(100, 1)
+	nodes := g.Nodes()
+	h, _ := g.ContractionHierarchy(context.Background(), nil)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s, e := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+		h.ShortestPath(s.Value(), e.Value())
+	}
+}
+
+// The same queries with bidirectional Dijkstra
+func BenchmarkContractionHierarchy_Bidirectional(b *testing.B) {
+	g := gridGraph(100, 1)
+	nodes := g.Nodes()
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s, e := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+		g.BidirectionalDijkstra(s, e)
+	}
+}
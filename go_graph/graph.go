@@ -4,14 +4,21 @@ package graph
 import (
 	"errors"
 	"fmt"
-	"math"
 	"sync"
 )
 
 // Node is the node in the graph.
 // It is accompanied with an int value
+// and optionally placed at a Point
 type Node struct {
 	value int
+	pos   *Point
+}
+
+// Point is the position of a node. In a geographic graph,
+// X is the longitude and Y the latitude, in degrees
+type Point struct {
+	X, Y float64
 }
 
 func (n *Node) String() string {
@@ -22,11 +29,67 @@ func (n *Node) Value() int {
 	return n.value
 }
 
+// Edge is a weighted edge in the graph.
+// It points to Node from the node it is stored under
+type Edge struct {
+	Node   *Node
+	Weight int
+
+	// The most flow the edge can carry, only used by
+	// the maximum flow algorithms
+	Capacity int
+}
+
+func (e *Edge) String() string {
+	return fmt.Sprintf("%v(%v)", e.Node, e.Weight)
+}
+
+// ErrNoPath is returned when the end of a path cannot
+// be reached from its start
+var ErrNoPath = errors.New("no path between nodes")
+
+// DuplicateNodeError is returned when adding a node
+// whose value is already in the graph
+type DuplicateNodeError struct {
+	Value int
+}
+
+func (e *DuplicateNodeError) Error() string {
+	return fmt.Sprintf("duplicate node %v", e.Value)
+}
+
+// NodeNotFoundError is returned when looking up a
+// value that is not in the graph
+type NodeNotFoundError struct {
+	Value int
+}
+
+func (e *NodeNotFoundError) Error() string {
+	return fmt.Sprintf("no such node %v", e.Value)
+}
+
 // ItemGraph is the Items graph
 type ItemGraph struct {
 	nodes []*Node
-	edges map[Node][]*Node
-	lock  sync.RWMutex
+
+	// Lookup of the nodes by value
+	index map[int]*Node
+
+	// Outgoing edges of each node. In an undirected graph,
+	// every edge is stored under both of its ends
+	edges map[Node][]*Edge
+
+	// Incoming edges of each node, only kept for directed graphs
+	inEdges map[Node][]*Edge
+
+	// The number of edges, counting an undirected edge once
+	numEdges int
+
+	// The number of edges of negative weight, counted the same way
+	negEdges int
+
+	directed bool
+	lock     sync.RWMutex
 }
 
 // Constructor of the graph
@@ -35,52 +98,211 @@ func NewGraph() *ItemGraph {
 	return g
 }
 
+// Constructor of a directed graph
+func NewDirectedGraph() *ItemGraph {
+	g := new(ItemGraph)
+	g.directed = true
+	return g
+}
+
+// Directed tells whether the edges of the graph are directed
+func (g *ItemGraph) Directed() bool {
+	return g.directed
+}
+
 func NewNode(v int) *Node {
 	n := new(Node)
 	n.value = v
 	return n
 }
 
-func (g *ItemGraph) FindNode(v int) (*Node, error) {
+// NewNodeAt returns a node with value v placed at p
+func NewNodeAt(v int, p Point) *Node {
+	n := NewNode(v)
+	n.pos = &p
+	return n
+}
+
+// Position returns where the node is placed, and
+// false if it was created without a position
+func (n *Node) Position() (Point, bool) {
+	if n.pos == nil {
+		return Point{}, false
+	}
+	return *n.pos, true
+}
+
+// NodeCount returns the number of nodes in the graph
+func (g *ItemGraph) NodeCount() int {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return len(g.nodes)
+}
+
+// EdgeCount returns the number of edges in the graph.
+// An undirected edge is counted once
+func (g *ItemGraph) EdgeCount() int {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.numEdges
+}
+
+// Nodes returns the nodes of the graph in the order they were added
+func (g *ItemGraph) Nodes() []*Node {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return append([]*Node(nil), g.nodes...)
+}
+
+// Edges returns the edges of the graph, grouped by the node they leave.
+// An undirected edge is listed once, from its end of lower value
+func (g *ItemGraph) Edges() []EdgeSpec {
+	specs, _ := g.EdgesWithCapacity()
+	return specs
+}
+
+// EdgesWithCapacity is like Edges, but also returns the capacity of
+// each edge, at the same index
+func (g *ItemGraph) EdgesWithCapacity() ([]EdgeSpec, []int) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	specs := make([]EdgeSpec, 0, g.numEdges)
+	capacities := make([]int, 0, g.numEdges)
 	for _, n := range g.nodes {
-		if n.value == v {
-			return n, nil
+		for _, e := range g.edges[*n] {
+			if g.directed || n.value <= e.Node.value {
+				specs = append(specs, EdgeSpec{From: n.value, To: e.Node.value, Weight: e.Weight})
+				capacities = append(capacities, e.Capacity)
+			}
 		}
 	}
-	return nil, errors.New("no such node")
+	return specs, capacities
 }
 
-// AddNode adds a node to the graph
-func (g *ItemGraph) AddNode(n *Node) {
+// FindNode returns the node of the graph with value v,
+// or a *NodeNotFoundError if there is none
+func (g *ItemGraph) FindNode(v int) (*Node, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if n, ok := g.index[v]; ok {
+		return n, nil
+	}
+	return nil, &NodeNotFoundError{Value: v}
+}
+
+// AddNode adds a node to the graph. It returns a *DuplicateNodeError
+// if the graph already has a node with the same value
+func (g *ItemGraph) AddNode(n *Node) error {
 	g.lock.Lock()
+	defer g.lock.Unlock()
+	if _, ok := g.index[n.value]; ok {
+		return &DuplicateNodeError{Value: n.value}
+	}
+	g.addNode(n)
+	return nil
+}
+
+// addNode records n in the graph and its index
+func (g *ItemGraph) addNode(n *Node) {
+	if g.index == nil {
+		g.index = make(map[int]*Node)
+	}
+	g.index[n.value] = n
 	g.nodes = append(g.nodes, n)
-	g.lock.Unlock()
 }
 
-// AddEdge adds an edge to the graph
+// AddEdge adds an edge of weight 1 to the graph
 func (g *ItemGraph) AddEdge(n1, n2 *Node) {
+	g.AddWeightedEdge(n1, n2, 1)
+}
+
+// AddWeightedEdge adds an edge with the given weight, and a capacity
+// of 0, to the graph. In a directed graph the edge goes from n1 to n2.
+// If the edge already exists, its weight is replaced
+func (g *ItemGraph) AddWeightedEdge(n1, n2 *Node, weight int) {
+	g.AddEdgeWithCapacity(n1, n2, weight, 0)
+}
+
+// AddEdgeWithCapacity is like AddWeightedEdge, but also sets the
+// capacity of the edge
+func (g *ItemGraph) AddEdgeWithCapacity(n1, n2 *Node, weight, capacity int) {
 	g.lock.Lock()
+	g.addEdge(n1, n2, weight, capacity)
+	g.lock.Unlock()
+}
+
+// addEdge stores the edge from n1 to n2 under both of its ends
+func (g *ItemGraph) addEdge(n1, n2 *Node, weight, capacity int) {
 	if g.edges == nil {
-		g.edges = make(map[Node][]*Node)
+		g.edges = make(map[Node][]*Edge)
+	}
+	if old := g.findEdge(n1, n2); old != nil && old.Weight < 0 {
+		g.negEdges--
+	}
+	if weight < 0 {
+		g.negEdges++
+	}
+	if setEdge(g.edges, n1, n2, weight, capacity) {
+		g.numEdges++
+	}
+	if g.directed {
+		if g.inEdges == nil {
+			g.inEdges = make(map[Node][]*Edge)
+		}
+		setEdge(g.inEdges, n2, n1, weight, capacity)
+	} else {
+		setEdge(g.edges, n2, n1, weight, capacity)
 	}
-	g.edges[*n1] = append(g.edges[*n1], n2)
-	g.edges[*n2] = append(g.edges[*n2], n1)
-	g.edges[*n1] = unique(g.edges[*n1])
-	g.edges[*n2] = unique(g.edges[*n2])
-	g.lock.Unlock()
 }
 
-// Remove duplicate elements
-func unique(intSlice []*Node) []*Node {
-	keys := make(map[*Node]bool)
-	list := []*Node{}
-	for _, entry := range intSlice {
-		if _, value := keys[entry]; !value {
-			keys[entry] = true
-			list = append(list, entry)
+// setEdge stores an edge to n2 under n1, without duplicates.
+// It tells whether the edge is new
+func setEdge(edges map[Node][]*Edge, n1, n2 *Node, weight, capacity int) bool {
+	for _, e := range edges[*n1] {
+		if e.Node == n2 {
+			e.Weight, e.Capacity = weight, capacity
+			return false
 		}
 	}
-	return list
+	edges[*n1] = append(edges[*n1], &Edge{Node: n2, Weight: weight, Capacity: capacity})
+	return true
+}
+
+// HasEdge tells whether the graph has an edge from n1 to n2
+func (g *ItemGraph) HasEdge(n1, n2 *Node) bool {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.findEdge(n1, n2) != nil
+}
+
+// findEdge returns the edge from n1 to n2, or nil
+func (g *ItemGraph) findEdge(n1, n2 *Node) *Edge {
+	for _, e := range g.edges[*n1] {
+		if e.Node == n2 {
+			return e
+		}
+	}
+	return nil
+}
+
+// OutNeighbors returns the edges leaving n.
+// In an undirected graph these are all the edges of n
+func (g *ItemGraph) OutNeighbors(n *Node) []*Edge {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return append([]*Edge(nil), g.edges[*n]...)
+}
+
+// InNeighbors returns the edges entering n, each pointing back to
+// the node it comes from. In an undirected graph these are all the
+// edges of n
+func (g *ItemGraph) InNeighbors(n *Node) []*Edge {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if !g.directed {
+		return append([]*Edge(nil), g.edges[*n]...)
+	}
+	return append([]*Edge(nil), g.inEdges[*n]...)
 }
 
 // Print out the graph
@@ -112,20 +334,21 @@ func (arr Array) hasPropertyOf(n *Node) bool {
 
 func (graph *ItemGraph) ShortestPath(start *Node, end *Node, path Array) []*Node {
 
-	if _, exist := graph.edges[*start]; !exist {
-		return path
-	}
-
 	path = append(path, start)
 	if start == end {
 		return path
 	}
 
+	// A node without outgoing edges is a dead end
+	if _, exist := graph.edges[*start]; !exist {
+		return nil
+	}
+
 	shortest := make([]*Node, 0)
 
-	for _, node := range graph.edges[*start] {
-		if !path.hasPropertyOf(node) {
-			newPath := graph.ShortestPath(node, end, path)
+	for _, e := range graph.edges[*start] {
+		if !path.hasPropertyOf(e.Node) {
+			newPath := graph.ShortestPath(e.Node, end, path)
 			if len(newPath) > 0 {
 				if len(shortest) == 0 || (len(newPath) < len(shortest)) {
 					shortest = newPath
@@ -137,69 +360,11 @@ func (graph *ItemGraph) ShortestPath(start *Node, end *Node, path Array) []*Node
 	return shortest
 }
 
-type Vertex struct {
-	Node     *Node
-	Distance int
-}
-
-func (g *ItemGraph) GetShortestPath(startNode *Node, endNode *Node) ([]int, int) {
-	visited := make(map[int]bool)
-	dist := make(map[int]int)
-	prev := make(map[int]int)
-
-	q := NodeQueue{}
-	pq := q.NewQ()
-	start := Vertex{
-		Node:     startNode,
-		Distance: 0,
-	}
-	for _, nval := range g.nodes {
-		dist[nval.Value()] = math.MaxInt64
-	}
-	dist[startNode.Value()] = start.Distance
-	pq.Enqueue(start)
-
-	for !pq.IsEmpty() {
-		v := pq.Dequeue()
-		if visited[v.Node.Value()] {
-			continue
-		}
-		visited[v.Node.Value()] = true
-		near := g.edges[*v.Node]
-
-		for _, val := range near {
-			if !visited[val.Value()] {
-				if dist[v.Node.Value()]+1 < dist[val.Value()] {
-					store := Vertex{
-						Node:     val,
-						Distance: dist[v.Node.Value()] + 1,
-					}
-					dist[val.Value()] = dist[v.Node.Value()] + 1
-					//prev[val.Node.Value] = fmt.Sprintf("->%s", v.Node.Value)
-					prev[val.Value()] = v.Node.Value()
-					pq.Enqueue(store)
-				}
-				//visited[val.Node.value] = true
-			}
-		}
-	}
-
-	// fmt.Println(dist)
-	// fmt.Println(prev)
-	pathval := prev[endNode.Value()]
-
-	var finalArr []int
-	finalArr = append(finalArr, endNode.Value())
-	for pathval != startNode.Value() {
-		// fmt.Printf("looping")
-		finalArr = append(finalArr, pathval)
-		pathval = prev[pathval]
-	}
-	finalArr = append(finalArr, pathval)
-	// fmt.Println(finalArr)
-	for i, j := 0, len(finalArr)-1; i < j; i, j = i+1, j-1 {
-		finalArr[i], finalArr[j] = finalArr[j], finalArr[i]
-	}
-	return finalArr, dist[endNode.Value()]
-
+// GetShortestPath finds the path of minimum total weight from startNode
+// to endNode using bidirectional Dijkstra. It returns the path along with
+// its cost, or ErrNoPath if endNode cannot be reached from startNode.
+// Edge weights must be non-negative
+func (g *ItemGraph) GetShortestPath(startNode *Node, endNode *Node) ([]int, int, error) {
+	path, cost, _, err := g.BidirectionalDijkstra(startNode, endNode)
+	return path, cost, err
 }
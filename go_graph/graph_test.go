@@ -0,0 +1,164 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+// values lists the nodes that the edges point to
+func values(edges []*Edge) []int {
+	var vs []int
+	for _, e := range edges {
+		vs = append(vs, e.Node.Value())
+	}
+	return vs
+}
+
+// equal tells whether a and b contain the same elements in the same order
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Test the neighbor accessors on directed and undirected graphs
+func TestItemGraph_Neighbors(t *testing.T) {
+
+	tests := []struct {
+		name string
+		g    *ItemGraph
+		out  []int
+		in   []int
+	}{
+		{"undirected", NewGraph(), []int{2, 3}, []int{2, 3}},
+		{"directed", NewDirectedGraph(), []int{2}, []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n1, n2, n3 := NewNode(1), NewNode(2), NewNode(3)
+			tt.g.AddNode(n1)
+			tt.g.AddNode(n2)
+			tt.g.AddNode(n3)
+			tt.g.AddEdge(n1, n2)
+			tt.g.AddEdge(n3, n1)
+
+			if out := values(tt.g.OutNeighbors(n1)); !equal(out, tt.out) {
+				t.Error("out-neighbors: expected", tt.out, "received", out)
+			}
+			if in := values(tt.g.InNeighbors(n1)); !equal(in, tt.in) {
+				t.Error("in-neighbors: expected", tt.in, "received", in)
+			}
+		})
+	}
+}
+
+// Test that AddNode indexes new nodes and rejects duplicate values
+func TestItemGraph_AddNode(t *testing.T) {
+	g := NewGraph()
+
+	if err := g.AddNode(NewNode(1)); err != nil {
+		t.Error("cannot add node", err)
+	}
+	if n, err := g.FindNode(1); err != nil || n.Value() != 1 {
+		t.Error("cannot find added node", err)
+	}
+	if _, err := g.FindNode(2); err == nil {
+		t.Error("found a node that was never added")
+	}
+
+	err := g.AddNode(NewNode(1))
+	var dup *DuplicateNodeError
+	if !errors.As(err, &dup) || dup.Value != 1 {
+		t.Error("duplicate node: expected DuplicateNodeError for 1, received", err)
+	}
+	if len(g.nodes) != 1 {
+		t.Error("nodes: expected", 1, "received", len(g.nodes))
+	}
+}
+
+// Test GetShortestPath when the end may not be reachable
+func TestItemGraph_GetShortestPath(t *testing.T) {
+
+	// Two components, 1-2-3 and 4-5, and an isolated node 6
+	g := NewGraph()
+	for v := 1; v <= 6; v++ {
+		g.AddNode(NewNode(v))
+	}
+	for _, e := range [][2]int{{1, 2}, {2, 3}, {4, 5}} {
+		n1, _ := g.FindNode(e[0])
+		n2, _ := g.FindNode(e[1])
+		g.AddEdge(n1, n2)
+	}
+
+	tests := []struct {
+		name string
+		s, t int
+		path []int
+		cost int
+		err  error
+	}{
+		{"connected", 1, 3, []int{1, 2, 3}, 2, nil},
+		{"self path", 2, 2, []int{2}, 0, nil},
+		{"isolated self path", 6, 6, []int{6}, 0, nil},
+		{"different components", 1, 5, nil, 0, ErrNoPath},
+		{"from isolated node", 6, 1, nil, 0, ErrNoPath},
+		{"to isolated node", 4, 6, nil, 0, ErrNoPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := g.FindNode(tt.s)
+			e, _ := g.FindNode(tt.t)
+
+			path, cost, err := g.GetShortestPath(s, e)
+
+			if err != tt.err {
+				t.Error("error: expected", tt.err, "received", err)
+			}
+			if !equal(path, tt.path) {
+				t.Error("path: expected", tt.path, "received", path)
+			}
+			if cost != tt.cost {
+				t.Error("cost: expected", tt.cost, "received", cost)
+			}
+		})
+	}
+}
+
+// Test that Edges lists every edge once
+func TestItemGraph_Edges(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		directed bool
+		edges    []EdgeSpec
+	}{
+		{"undirected", false, []EdgeSpec{{From: 1, To: 2, Weight: 4}, {From: 1, To: 3, Weight: 1}, {From: 3, To: 3, Weight: 2}}},
+		{"directed", true, []EdgeSpec{{From: 2, To: 1, Weight: 4}, {From: 3, To: 1, Weight: 1}, {From: 3, To: 3, Weight: 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGraph(tt.directed, 3, []EdgeSpec{
+				{From: 2, To: 1, Weight: 4}, {From: 3, To: 1, Weight: 1}, {From: 3, To: 3, Weight: 2},
+			})
+
+			edges := g.Edges()
+			if len(edges) != len(tt.edges) || len(edges) != g.EdgeCount() {
+				t.Fatal("edges: expected", tt.edges, "received", edges)
+			}
+			for i, e := range edges {
+				if e != tt.edges[i] {
+					t.Error("edge: expected", tt.edges[i], "received", e)
+				}
+			}
+		})
+	}
+}
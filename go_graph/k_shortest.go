@@ -0,0 +1,166 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// Path is a path through the graph, as the values of its nodes,
+// along with its cost
+type Path struct {
+	Nodes []int
+	Cost  int
+}
+
+// KShortestPaths finds up to k paths from start to end that visit no
+// node twice, in order of cost, using Yen's algorithm. It returns fewer
+// paths if there are not k of them, and ErrNoPath if there is none.
+// Edge weights must be non-negative
+func (g *ItemGraph) KShortestPaths(start, end *Node, k int) ([]Path, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if k <= 0 {
+		return nil, nil
+	}
+	first, ok := g.restrictedPath(start.value, end.value, nil, nil)
+	if !ok {
+		return nil, ErrNoPath
+	}
+
+	paths := []Path{first}
+	candidates := &pathHeap{}
+	seen := map[string]bool{pathKey(first.Nodes): true}
+
+	for len(paths) < k {
+		prev := paths[len(paths)-1].Nodes
+		costs := g.prefixCosts(prev)
+
+		// Branch off the last path at each of its nodes
+		for i := 0; i < len(prev)-1; i++ {
+			root := prev[:i+1]
+
+			// Leave the edges taken from the same root by the paths
+			// found so far, and the nodes of the root, to the spur
+			blockedEdges := make(map[[2]int]bool)
+			for _, p := range paths {
+				if len(p.Nodes) > i+1 && equalValues(p.Nodes[:i+1], root) {
+					blockedEdges[[2]int{p.Nodes[i], p.Nodes[i+1]}] = true
+				}
+			}
+			blockedNodes := make(map[int]bool)
+			for _, v := range root[:i] {
+				blockedNodes[v] = true
+			}
+
+			spur, ok := g.restrictedPath(prev[i], end.value, blockedNodes, blockedEdges)
+			if !ok {
+				continue
+			}
+			nodes := append(append([]int(nil), root[:i]...), spur.Nodes...)
+			if key := pathKey(nodes); !seen[key] {
+				seen[key] = true
+				heap.Push(candidates, Path{Nodes: nodes, Cost: costs[i] + spur.Cost})
+			}
+		}
+
+		if candidates.Len() == 0 {
+			break
+		}
+		paths = append(paths, heap.Pop(candidates).(Path))
+	}
+	return paths, nil
+}
+
+// restrictedPath runs Dijkstra's algorithm from the node with value src
+// to the node with value dst, avoiding the blocked nodes and edges
+func (g *ItemGraph) restrictedPath(src, dst int, blockedNodes map[int]bool, blockedEdges map[[2]int]bool) (Path, bool) {
+	dist := make(map[int]int)
+	prev := make(map[int]int)
+	best := map[int]int{src: 0}
+
+	pq := NewNodeQueue()
+	pq.Enqueue(Vertex{Node: g.index[src], Distance: 0})
+	for !pq.IsEmpty() {
+		v := pq.Dequeue()
+		dist[v.Node.value] = v.Distance
+		if v.Node.value == dst {
+			break
+		}
+
+		for _, e := range g.edges[*v.Node] {
+			u := e.Node.value
+			if _, settled := dist[u]; settled || blockedNodes[u] || blockedEdges[[2]int{v.Node.value, u}] {
+				continue
+			}
+			d := v.Distance + e.Weight
+			if b, ok := best[u]; !ok || d < b {
+				best[u] = d
+				prev[u] = v.Node.value
+				pq.Enqueue(Vertex{Node: e.Node, Distance: d})
+			}
+		}
+	}
+
+	cost, ok := dist[dst]
+	if !ok {
+		return Path{}, false
+	}
+	return Path{Nodes: tracePath(prev, src, dst), Cost: cost}, true
+}
+
+// prefixCosts returns the cost of each prefix of the path through
+// the given nodes, up to and including each node
+func (g *ItemGraph) prefixCosts(nodes []int) []int {
+	costs := make([]int, len(nodes))
+	for i := 1; i < len(nodes); i++ {
+		costs[i] = costs[i-1] + g.findEdge(g.index[nodes[i-1]], g.index[nodes[i]]).Weight
+	}
+	return costs
+}
+
+// pathKey identifies the path through the given nodes
+func pathKey(nodes []int) string {
+	return fmt.Sprint(nodes)
+}
+
+// equalValues tells whether a and b hold the same values in order
+func equalValues(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// pathHeap is a heap of paths, the cheapest and then the
+// shortest on top, ties broken by the order of node values
+type pathHeap []Path
+
+func (h pathHeap) Len() int { return len(h) }
+func (h pathHeap) Less(i, j int) bool {
+	if h[i].Cost != h[j].Cost {
+		return h[i].Cost < h[j].Cost
+	}
+	if len(h[i].Nodes) != len(h[j].Nodes) {
+		return len(h[i].Nodes) < len(h[j].Nodes)
+	}
+	for k := range h[i].Nodes {
+		if h[i].Nodes[k] != h[j].Nodes[k] {
+			return h[i].Nodes[k] < h[j].Nodes[k]
+		}
+	}
+	return false
+}
+func (h pathHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *pathHeap) Push(x interface{}) { *h = append(*h, x.(Path)) }
+func (h *pathHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
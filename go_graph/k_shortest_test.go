@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Test Yen's algorithm on the example of its Wikipedia article,
+// with C, D, E, F, G, H numbered 1 to 6
+func TestItemGraph_KShortestPaths(t *testing.T) {
+	g := newTestGraph(true, 6, []EdgeSpec{
+		{From: 1, To: 2, Weight: 3},
+		{From: 1, To: 3, Weight: 2},
+		{From: 2, To: 4, Weight: 4},
+		{From: 3, To: 2, Weight: 1},
+		{From: 3, To: 4, Weight: 2},
+		{From: 3, To: 5, Weight: 3},
+		{From: 4, To: 5, Weight: 2},
+		{From: 4, To: 6, Weight: 1},
+		{From: 5, To: 6, Weight: 2},
+	})
+	start, _ := g.FindNode(1)
+	end, _ := g.FindNode(6)
+
+	want := []Path{
+		{Nodes: []int{1, 3, 4, 6}, Cost: 5},
+		{Nodes: []int{1, 3, 5, 6}, Cost: 7},
+		{Nodes: []int{1, 2, 4, 6}, Cost: 8},
+	}
+	paths, err := g.KShortestPaths(start, end, 3)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if len(paths) != len(want) {
+		t.Fatal("paths: expected", want, "received", paths)
+	}
+	for i := range want {
+		if !equal(paths[i].Nodes, want[i].Nodes) || paths[i].Cost != want[i].Cost {
+			t.Error("path", i, ": expected", want[i], "received", paths[i])
+		}
+	}
+
+	// There are 7 simple paths in all
+	paths, _ = g.KShortestPaths(start, end, 100)
+	if len(paths) != 7 {
+		t.Error("all paths: expected", 7, "received", len(paths))
+	}
+
+	// No path back to the start
+	if _, err := g.KShortestPaths(end, start, 3); err != ErrNoPath {
+		t.Error("expected", ErrNoPath, "received", err)
+	}
+}
+
+// simplePathCosts returns the costs of every path from v to end that
+// does not visit a node twice
+func simplePathCosts(g *ItemGraph, v, end *Node, visited map[int]bool, cost int, costs *[]int) {
+	if v == end {
+		*costs = append(*costs, cost)
+		return
+	}
+	visited[v.value] = true
+	for _, e := range g.edges[*v] {
+		if !visited[e.Node.value] {
+			simplePathCosts(g, e.Node, end, visited, cost+e.Weight, costs)
+		}
+	}
+	visited[v.value] = false
+}
+
+// Test the costs of the paths against all simple paths
+// of small random graphs
+func TestItemGraph_KShortestPathsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for round := 0; round < 20; round++ {
+		g := NewGraph()
+		if round%2 == 0 {
+			g = NewDirectedGraph()
+		}
+		nodes := make([]*Node, 7)
+		for i := range nodes {
+			nodes[i] = NewNode(i)
+			g.AddNode(nodes[i])
+		}
+		for i := 0; i < 14; i++ {
+			a, b := nodes[r.Intn(7)], nodes[r.Intn(7)]
+			if a != b {
+				g.AddWeightedEdge(a, b, r.Intn(10))
+			}
+		}
+
+		var all []int
+		simplePathCosts(g, nodes[0], nodes[6], make(map[int]bool), 0, &all)
+		sort.Ints(all)
+
+		paths, err := g.KShortestPaths(nodes[0], nodes[6], 10)
+		if len(all) == 0 {
+			if err != ErrNoPath {
+				t.Error("round", round, ": expected", ErrNoPath, "received", err)
+			}
+			continue
+		}
+		if len(all) > 10 {
+			all = all[:10]
+		}
+		if len(paths) != len(all) {
+			t.Fatal("round", round, ": expected", len(all), "paths, received", paths)
+		}
+		seen := make(map[string]bool)
+		for i, p := range paths {
+			if p.Cost != all[i] {
+				t.Error("round", round, ": path", i, "cost expected", all[i], "received", p.Cost)
+			}
+			if seen[pathKey(p.Nodes)] {
+				t.Error("round", round, ": path", p.Nodes, "found twice")
+			}
+			seen[pathKey(p.Nodes)] = true
+		}
+	}
+}
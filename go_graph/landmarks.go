@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"context"
+	"math/rand"
+)
+
+// LandmarkSelection is how Landmarks picks its landmark nodes
+type LandmarkSelection int
+
+const (
+	// FarthestLandmarks picks each landmark as far as possible from
+	// those picked before it, the first one farthest from a random
+	// node. Nodes out of reach of every landmark are picked first,
+	// so that each part of a disconnected graph gets one
+	FarthestLandmarks LandmarkSelection = iota
+
+	// RandomLandmarks picks the landmarks uniformly at random
+	RandomLandmarks
+)
+
+// Landmarks holds the costs of the shortest paths between a few landmark
+// nodes and every node of a snapshot of a graph. By the triangle
+// inequality, they bound from below the cost of a path between any two
+// nodes, which lets ALT search toward the end of the path on graphs
+// without positions. It is safe for concurrent use
+type Landmarks struct {
+	landmarks []int
+	index     map[int]int
+
+	// The distance from each landmark to every node, and from
+	// every node to each landmark, by node number. They are the
+	// same in an undirected graph. Nodes with no path between
+	// them are at distance Unreachable
+	from [][]int
+	to   [][]int
+}
+
+// Values returns the values of the landmark nodes, in the order
+// they were picked
+func (lm *Landmarks) Values() []int {
+	return append([]int(nil), lm.landmarks...)
+}
+
+// Landmarks picks k landmarks of the graph as it is when called, using r
+// for any random choice, and finds the costs of the shortest paths
+// between them and every node. It reports to progress, if not nil, each
+// time a landmark is done. It stops with the error of ctx if ctx is done
+// first, and returns ErrNegativeWeights if any edge has a negative weight
+func (g *ItemGraph) Landmarks(ctx context.Context, k int, selection LandmarkSelection, r *rand.Rand, progress func(done, total int)) (*Landmarks, error) {
+	g.lock.RLock()
+	if g.negEdges > 0 {
+		g.lock.RUnlock()
+		return nil, ErrNegativeWeights
+	}
+
+	// Number the nodes, so that the searches work on slices
+	n := len(g.nodes)
+	lm := &Landmarks{index: make(map[int]int, n)}
+	values := make([]int, n)
+	for i, v := range g.nodes {
+		values[i] = v.value
+		lm.index[v.value] = i
+	}
+	out := make([][]arc, n)
+	in := make([][]arc, n)
+	for i, v := range g.nodes {
+		for _, e := range g.edges[*v] {
+			j := lm.index[e.Node.value]
+			out[i] = append(out[i], arc{to: j, weight: e.Weight})
+			in[j] = append(in[j], arc{to: i, weight: e.Weight})
+		}
+	}
+	directed := g.directed
+	g.lock.RUnlock()
+
+	if k > n {
+		k = n
+	}
+	distances := func(adj [][]arc, source int) []int {
+		dist := make([]int, n)
+		for i := range dist {
+			dist[i] = Unreachable
+		}
+		reweightedDistances(adj, source, dist)
+		return dist
+	}
+
+	var picks []int
+	if selection == RandomLandmarks {
+		picks = r.Perm(n)[:k]
+	}
+
+	// The distance of each node to the nearest landmark, either way
+	var nearest []int
+	if selection == FarthestLandmarks && n > 0 {
+		nearest = distances(out, r.Intn(n))
+	}
+	picked := make([]bool, n)
+
+	for l := 0; l < k; l++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var v int
+		if selection == RandomLandmarks {
+			v = picks[l]
+		} else {
+			v = farthest(nearest, picked)
+		}
+		picked[v] = true
+
+		from := distances(out, v)
+		to := from
+		if directed {
+			to = distances(in, v)
+		}
+		lm.landmarks = append(lm.landmarks, values[v])
+		lm.from = append(lm.from, from)
+		lm.to = append(lm.to, to)
+
+		if nearest != nil {
+			for i := range nearest {
+				if l == 0 || from[i] < nearest[i] {
+					nearest[i] = from[i]
+				}
+				if to[i] < nearest[i] {
+					nearest[i] = to[i]
+				}
+			}
+		}
+		if progress != nil {
+			progress(l+1, k)
+		}
+	}
+	return lm, nil
+}
+
+// farthest returns the number of the node not picked yet with the
+// greatest distance, the first one in case of a tie
+func farthest(dist []int, picked []bool) int {
+	best := -1
+	for i, d := range dist {
+		if !picked[i] && (best < 0 || d > dist[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// bound returns a lower bound of the cost of a path from the node
+// numbered i to the node numbered j
+func (lm *Landmarks) bound(i, j int) int {
+	best := 0
+	for l := range lm.from {
+		// d(l, j) <= d(l, i) + d(i, j)
+		if a, b := lm.from[l][j], lm.from[l][i]; a != Unreachable && b != Unreachable && a-b > best {
+			best = a - b
+		}
+		// d(i, l) <= d(i, j) + d(j, l)
+		if a, b := lm.to[l][i], lm.to[l][j]; a != Unreachable && b != Unreachable && a-b > best {
+			best = a - b
+		}
+	}
+	return best
+}
+
+// Estimate implements Heuristic, with the greatest lower bound given
+// by the landmarks. It is 0 for nodes added after the landmarks
+func (lm *Landmarks) Estimate(from, to *Node) int {
+	i, ok1 := lm.index[from.value]
+	j, ok2 := lm.index[to.value]
+	if !ok1 || !ok2 {
+		return 0
+	}
+	return lm.bound(i, j)
+}
+
+// ALT finds the shortest path from start to end, along with its cost,
+// by A* search with the lower bounds given by the landmarks lm. It also
+// returns the number of nodes it settled. Without landmarks, it is
+// Dijkstra's algorithm stopping at end. It returns ErrNoPath if end
+// cannot be reached. Edge weights must be non-negative, and lm must
+// have been found on the graph as it is
+func (g *ItemGraph) ALT(start, end *Node, lm *Landmarks) ([]int, int, int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	// Every estimate is toward end, so look it up once
+	h := HeuristicFunc(func(from, to *Node) int { return 0 })
+	if lm != nil {
+		if j, ok := lm.index[end.value]; ok {
+			h = func(from, to *Node) int {
+				if i, ok := lm.index[from.value]; ok {
+					return lm.bound(i, j)
+				}
+				return 0
+			}
+		}
+	}
+	return g.aStar(start, end, h)
+}
@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+// Test that ALT finds paths as cheap as Dijkstra's algorithm with both
+// ways of picking landmarks, that the landmarks never overestimate, and
+// that they save work on a grid
+func TestItemGraph_ALT(t *testing.T) {
+	for _, directed := range []bool{false, true} {
+		for _, selection := range []LandmarkSelection{FarthestLandmarks, RandomLandmarks} {
+			r := rand.New(rand.NewSource(6))
+			g := NewGraph()
+			if directed {
+				g = NewDirectedGraph()
+			}
+			nodes := make([]*Node, 200)
+			for i := range nodes {
+				nodes[i] = NewNode(i)
+				g.AddNode(nodes[i])
+			}
+			for k := 0; k < 500; k++ {
+				g.AddWeightedEdge(nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))], r.Intn(30))
+			}
+
+			lm, err := g.Landmarks(context.Background(), 8, selection, r, nil)
+			if err != nil {
+				t.Fatal("Landmarks:", err)
+			}
+			if len(lm.Values()) != 8 {
+				t.Fatal("landmarks: expected 8, received", lm.Values())
+			}
+
+			for i := 0; i < 300; i++ {
+				s, e := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+				_, want, wantErr := g.ShortestPathTree(s).PathTo(e.Value())
+				if est := lm.Estimate(s, e); wantErr == nil && est > want {
+					t.Fatal("estimate from", s, "to", e, ": expected at most", want, "received", est)
+				}
+				path, cost, _, err := g.ALT(s, e, lm)
+				if err != wantErr || cost != want {
+					t.Fatal("directed", directed, selection, ": path from", s, "to", e, ": expected", want, wantErr, "received", cost, err)
+				}
+				if err == nil && (path[0] != s.Value() || path[len(path)-1] != e.Value() || g.pathCost(path) != cost) {
+					t.Fatal("directed", directed, selection, ": path from", s, "to", e, "costs", cost, "received", path)
+				}
+			}
+		}
+	}
+
+	g := gridGraph(30, 2)
+	nodes := g.Nodes()
+	lm, _ := g.Landmarks(context.Background(), 4, FarthestLandmarks, rand.New(rand.NewSource(1)), nil)
+	_, _, settled, _ := g.ALT(nodes[0], nodes[len(nodes)-1], lm)
+	_, _, plain, _ := g.ALT(nodes[0], nodes[len(nodes)-1], nil)
+	if settled >= plain {
+		t.Error("settled: expected fewer than", plain, "nodes with landmarks, received", settled)
+	}
+}
+
+// Test that farthest landmarks go to the ends of a line, and to
+// every part of a disconnected graph
+func TestItemGraph_FarthestLandmarks(t *testing.T) {
+	line := newTestGraph(false, 9, []EdgeSpec{
+		{1, 2, 1}, {2, 3, 1}, {3, 4, 1}, {4, 5, 1}, {5, 6, 1}, {6, 7, 1}, {7, 8, 1}, {8, 9, 1},
+	})
+	for seed := int64(0); seed < 5; seed++ {
+		lm, _ := line.Landmarks(context.Background(), 2, FarthestLandmarks, rand.New(rand.NewSource(seed)), nil)
+		v := lm.Values()
+		if !(v[0] == 1 && v[1] == 9) && !(v[0] == 9 && v[1] == 1) {
+			t.Error("line, seed", seed, ": expected 1 and 9, received", v)
+		}
+	}
+
+	parts := newTestGraph(true, 6, []EdgeSpec{{1, 2, 1}, {2, 3, 1}, {4, 5, 1}, {5, 6, 1}})
+	lm, _ := parts.Landmarks(context.Background(), 2, FarthestLandmarks, rand.New(rand.NewSource(1)), nil)
+	if v := lm.Values(); (v[0] <= 3) == (v[1] <= 3) {
+		t.Error("parts: expected a landmark in each part, received", v)
+	}
+}
+
+// Test that landmarks cannot be found with negative weights, and
+// that finding them stops when their context is canceled
+func TestItemGraph_LandmarksErrors(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := newTestGraph(true, 3, []EdgeSpec{{1, 2, 1}, {2, 3, -1}})
+	if _, err := g.Landmarks(context.Background(), 2, RandomLandmarks, r, nil); err != ErrNegativeWeights {
+		t.Error("negative weights: expected", ErrNegativeWeights, "received", err)
+	}
+
+	g, _ = randomGraph(100)
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := g.Landmarks(ctx, 8, FarthestLandmarks, r, func(done, total int) {
+		if done == 3 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Error("canceled: expected", context.Canceled, "received", err)
+	}
+}
+
+// Point-to-point queries on a 100 by 100 grid with 16 landmarks
+func BenchmarkALT(b *testing.B) {
+	g := gridGraph(100, 1)
+	nodes := g.Nodes()
+	lm, _ := g.Landmarks(context.Background(), 16, FarthestLandmarks, rand.New(rand.NewSource(1)), nil)
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s, e := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+		g.ALT(s, e, lm)
+	}
+}
+
+// The same queries without landmarks
+func BenchmarkALT_NoLandmarks(b *testing.B) {
+	g := gridGraph(100, 1)
+	nodes := g.Nodes()
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s, e := nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))]
+		g.ALT(s, e, nil)
+	}
+}
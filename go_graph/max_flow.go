@@ -0,0 +1,283 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrSourceIsSink is returned when a flow is asked from a node to itself
+var ErrSourceIsSink = errors.New("source and sink are the same node")
+
+// EdgeFlow is the flow along an edge, from From to To
+type EdgeFlow struct {
+	From int
+	To   int
+	Flow int
+}
+
+// Flow is a maximum flow from a source node to a sink node, along
+// with a minimum cut between them
+type Flow struct {
+	// The total flow from the source to the sink, which is also
+	// the total capacity of the edges across the cut
+	Value int
+
+	// The edges carrying flow, in the order of Edges. The flow
+	// of an undirected edge is given in the direction it goes
+	Edges []EdgeFlow
+
+	// The values of the nodes on each side of the cut, in ascending
+	// order. The source side holds the nodes to which the source
+	// could still send more flow, and every edge from it to the
+	// sink side is saturated
+	SourceSide []int
+	SinkSide   []int
+}
+
+// Dinic finds a maximum flow from source to sink through the edges of
+// the graph, up to their capacities, with Dinic's algorithm in O(V^2 E)
+// time. Each phase finds the shortest paths with spare capacity by a
+// breadth-first search, and saturates them by depth-first searches. An
+// undirected edge carries flow either way. Edges of capacity 0 or less
+// carry none. It returns ErrSourceIsSink if source and sink are the
+// same node, and a *NodeNotFoundError if either is not in the graph
+func (g *ItemGraph) Dinic(source, sink *Node) (*Flow, error) {
+	net, err := g.flowNetwork(source, sink)
+	if err != nil {
+		return nil, err
+	}
+
+	level := make([]int, len(net.arcs))
+	next := make([]int, len(net.arcs))
+	for net.levels(level) {
+		for i := range next {
+			next[i] = 0
+		}
+		for net.augment(net.source, math.MaxInt, level, next) > 0 {
+		}
+	}
+	return net.flow(), nil
+}
+
+// PushRelabel finds a maximum flow like Dinic, with the FIFO
+// push-relabel algorithm in O(V^3) time. The source first floods its
+// edges, and each node with more flow coming in than going out pushes
+// the excess to lower neighbors, rising when it has none, until all of
+// it reaches the sink or comes back to the source
+func (g *ItemGraph) PushRelabel(source, sink *Node) (*Flow, error) {
+	net, err := g.flowNetwork(source, sink)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(net.arcs)
+	height := make([]int, n)
+	excess := make([]int, n)
+	current := make([]int, n)
+	queued := make([]bool, n)
+	var queue []int
+
+	push := func(v, a, f int) {
+		w := net.to[a]
+		net.residual[a] -= f
+		net.residual[a^1] += f
+		excess[v] -= f
+		excess[w] += f
+		if w != net.source && w != net.sink && !queued[w] {
+			queued[w] = true
+			queue = append(queue, w)
+		}
+	}
+
+	height[net.source] = n
+	for _, a := range net.arcs[net.source] {
+		if net.residual[a] > 0 {
+			push(net.source, a, net.residual[a])
+		}
+	}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		queued[v] = false
+
+		for excess[v] > 0 {
+			if current[v] == len(net.arcs[v]) {
+				// No neighbor is lower: rise just above the
+				// lowest one with spare capacity. The arc the
+				// excess came in by always has some back
+				h := math.MaxInt
+				for _, a := range net.arcs[v] {
+					if net.residual[a] > 0 && height[net.to[a]]+1 < h {
+						h = height[net.to[a]] + 1
+					}
+				}
+				height[v] = h
+				current[v] = 0
+				continue
+			}
+			a := net.arcs[v][current[v]]
+			if net.residual[a] > 0 && height[v] == height[net.to[a]]+1 {
+				f := excess[v]
+				if net.residual[a] < f {
+					f = net.residual[a]
+				}
+				push(v, a, f)
+			} else {
+				current[v]++
+			}
+		}
+	}
+	return net.flow(), nil
+}
+
+// flowNetwork is the residual network of a snapshot of a graph, over
+// the nodes numbered in ascending order of value. Each edge is a pair
+// of arcs, numbered 2k forward and 2k+1 back, whose residual capacity
+// is how much more flow they can take
+type flowNetwork struct {
+	values       []int
+	source, sink int
+
+	// The arcs leaving each node
+	arcs [][]int
+
+	// The node each arc goes to, its residual capacity, and
+	// that capacity before any flow
+	to       []int
+	residual []int
+	capacity []int
+
+	// The edge of each pair of arcs
+	edges []EdgeSpec
+}
+
+func (g *ItemGraph) flowNetwork(source, sink *Node) (*flowNetwork, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	net := &flowNetwork{values: make([]int, len(g.nodes))}
+	for i, n := range g.nodes {
+		net.values[i] = n.value
+	}
+	sort.Ints(net.values)
+	index := make(map[int]int, len(net.values))
+	for i, v := range net.values {
+		index[v] = i
+	}
+	var ok bool
+	if net.source, ok = index[source.value]; !ok {
+		return nil, &NodeNotFoundError{Value: source.value}
+	}
+	if net.sink, ok = index[sink.value]; !ok {
+		return nil, &NodeNotFoundError{Value: sink.value}
+	}
+	if net.source == net.sink {
+		return nil, ErrSourceIsSink
+	}
+
+	net.arcs = make([][]int, len(net.values))
+	for _, n := range g.nodes {
+		for _, e := range g.edges[*n] {
+			if n.value == e.Node.value || (!g.directed && n.value > e.Node.value) {
+				continue
+			}
+			c := e.Capacity
+			if c < 0 {
+				c = 0
+			}
+			// An undirected edge can take its capacity
+			// either way
+			back := 0
+			if !g.directed {
+				back = c
+			}
+			net.addArc(index[n.value], index[e.Node.value], c)
+			net.addArc(index[e.Node.value], index[n.value], back)
+			net.edges = append(net.edges, EdgeSpec{From: n.value, To: e.Node.value})
+		}
+	}
+	return net, nil
+}
+
+func (net *flowNetwork) addArc(u, v, capacity int) {
+	net.arcs[u] = append(net.arcs[u], len(net.to))
+	net.to = append(net.to, v)
+	net.residual = append(net.residual, capacity)
+	net.capacity = append(net.capacity, capacity)
+}
+
+// levels sets the number of arcs with spare capacity on the shortest
+// path from the source to each node, or -1 for the nodes out of reach,
+// and tells whether the sink is in reach
+func (net *flowNetwork) levels(level []int) bool {
+	for i := range level {
+		level[i] = -1
+	}
+	level[net.source] = 0
+	queue := []int{net.source}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, a := range net.arcs[v] {
+			if w := net.to[a]; net.residual[a] > 0 && level[w] < 0 {
+				level[w] = level[v] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return level[net.sink] >= 0
+}
+
+// augment sends up to limit more flow from v to the sink, along arcs
+// that each go one level up, and returns how much it sent. The arcs
+// of each node before next are known to lead nowhere in this phase
+func (net *flowNetwork) augment(v, limit int, level, next []int) int {
+	if v == net.sink {
+		return limit
+	}
+	for ; next[v] < len(net.arcs[v]); next[v]++ {
+		a := net.arcs[v][next[v]]
+		w := net.to[a]
+		if net.residual[a] == 0 || level[w] != level[v]+1 {
+			continue
+		}
+		f := limit
+		if net.residual[a] < f {
+			f = net.residual[a]
+		}
+		if f = net.augment(w, f, level, next); f > 0 {
+			net.residual[a] -= f
+			net.residual[a^1] += f
+			return f
+		}
+	}
+	return 0
+}
+
+// flow reads the flow and the cut off the residual network
+func (net *flowNetwork) flow() *Flow {
+	res := &Flow{}
+	for _, a := range net.arcs[net.source] {
+		res.Value += net.capacity[a] - net.residual[a]
+	}
+	for k, e := range net.edges {
+		switch f := net.capacity[2*k] - net.residual[2*k]; {
+		case f > 0:
+			res.Edges = append(res.Edges, EdgeFlow{From: e.From, To: e.To, Flow: f})
+		case f < 0:
+			res.Edges = append(res.Edges, EdgeFlow{From: e.To, To: e.From, Flow: -f})
+		}
+	}
+
+	level := make([]int, len(net.arcs))
+	net.levels(level)
+	for i, v := range net.values {
+		if level[i] >= 0 {
+			res.SourceSide = append(res.SourceSide, v)
+		} else {
+			res.SinkSide = append(res.SinkSide, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// newFlowGraph is like newTestGraph, but the edges are
+// {from, to, capacity}, all of weight 1
+func newFlowGraph(directed bool, n int, edges [][3]int) *ItemGraph {
+	g := newTestGraph(directed, n, nil)
+	specs := make([]EdgeSpec, len(edges))
+	capacities := make([]int, len(edges))
+	for i, e := range edges {
+		specs[i] = EdgeSpec{From: e[0], To: e[1], Weight: 1}
+		capacities[i] = e[2]
+	}
+	g.AddEdgesWithCapacity(specs, capacities)
+	return g
+}
+
+// checkFlow fails the test unless f is a flow from source to sink of
+// g within the capacities, and its cut is a cut of the same capacity
+func checkFlow(t *testing.T, g *ItemGraph, source, sink int, f *Flow) {
+	t.Helper()
+	specs, capacities := g.EdgesWithCapacity()
+	capacity := make(map[[2]int]int)
+	for i, e := range specs {
+		if e.From == e.To {
+			continue
+		}
+		if capacities[i] > 0 {
+			capacity[[2]int{e.From, e.To}] = capacities[i]
+			if !g.Directed() {
+				capacity[[2]int{e.To, e.From}] = capacities[i]
+			}
+		}
+	}
+
+	net := make(map[int]int)
+	for _, e := range f.Edges {
+		if e.Flow <= 0 || e.Flow > capacity[[2]int{e.From, e.To}] {
+			t.Fatal("edge", e, ": flow out of capacity", capacity[[2]int{e.From, e.To}])
+		}
+		net[e.From] -= e.Flow
+		net[e.To] += e.Flow
+	}
+	for v, x := range net {
+		if v != source && v != sink && x != 0 {
+			t.Fatal("node", v, ": expected as much flow in as out, received a difference of", x)
+		}
+	}
+	if net[sink] != f.Value || net[source] != -f.Value {
+		t.Fatal("expected a flow of", f.Value, "into the sink, received", net[sink], "out of the source", -net[source])
+	}
+
+	sourceSide := make(map[int]bool)
+	for _, v := range f.SourceSide {
+		sourceSide[v] = true
+	}
+	if !sourceSide[source] || sourceSide[sink] || len(f.SourceSide)+len(f.SinkSide) != g.NodeCount() {
+		t.Fatal("expected a cut between", source, "and", sink, "received", f.SourceSide, f.SinkSide)
+	}
+	cut := 0
+	for pair, c := range capacity {
+		if sourceSide[pair[0]] && !sourceSide[pair[1]] {
+			cut += c
+		}
+	}
+	if cut != f.Value {
+		t.Fatal("expected a cut of capacity", f.Value, "received", cut)
+	}
+}
+
+// Test both algorithms on a small directed network
+func TestItemGraph_MaxFlow(t *testing.T) {
+	g := newFlowGraph(true, 6, [][3]int{
+		{1, 2, 16}, {1, 3, 13}, {2, 4, 12}, {3, 2, 4}, {3, 5, 14},
+		{4, 3, 9}, {4, 6, 20}, {5, 4, 7}, {5, 6, 4},
+	})
+	source, _ := g.FindNode(1)
+	sink, _ := g.FindNode(6)
+
+	tests := []struct {
+		name string
+		flow func(source, sink *Node) (*Flow, error)
+	}{
+		{"Dinic", g.Dinic},
+		{"PushRelabel", g.PushRelabel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.flow(source, sink)
+			if err != nil || f.Value != 23 {
+				t.Fatal("expected a flow of 23, received", f, err)
+			}
+			checkFlow(t, g, 1, 6, f)
+			if !equal(f.SourceSide, []int{1, 2, 3, 5}) || !equal(f.SinkSide, []int{4, 6}) {
+				t.Error("expected a cut between [1 2 3 5] and [4 6], received", f.SourceSide, f.SinkSide)
+			}
+
+			if _, err := tt.flow(source, source); err != ErrSourceIsSink {
+				t.Error("same node: expected", ErrSourceIsSink, "received", err)
+			}
+			if _, err := tt.flow(source, NewNode(7)); err == nil {
+				t.Error("missing sink: expected an error, received none")
+			}
+		})
+	}
+}
+
+// Test that an undirected edge carries flow either way
+func TestItemGraph_MaxFlowUndirected(t *testing.T) {
+	// All the flow goes through 3, which can only send part of
+	// it straight to 4, and the rest through 2-3 to 2
+	g := newFlowGraph(false, 4, [][3]int{
+		{1, 3, 5}, {2, 3, 4}, {2, 4, 6}, {3, 4, 2},
+	})
+	source, _ := g.FindNode(1)
+	sink, _ := g.FindNode(4)
+	for _, flow := range []func(source, sink *Node) (*Flow, error){g.Dinic, g.PushRelabel} {
+		f, err := flow(source, sink)
+		if err != nil || f.Value != 5 {
+			t.Fatal("expected a flow of 5, received", f, err)
+		}
+		checkFlow(t, g, 1, 4, f)
+		for _, e := range f.Edges {
+			if e.From == 2 && e.To == 3 {
+				t.Error("expected the flow to go from 3 to 2, received", e)
+			}
+		}
+	}
+}
+
+// Test that both algorithms find flows of the same value on random
+// graphs, matching the capacity of their cuts
+func TestItemGraph_MaxFlowRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	for round := 0; round < 40; round++ {
+		directed := round%2 == 0
+		edges := make([][3]int, 120)
+		for i := range edges {
+			edges[i] = [3]int{1 + r.Intn(30), 1 + r.Intn(30), r.Intn(20)}
+		}
+		g := newFlowGraph(directed, 30, edges)
+		source, _ := g.FindNode(1)
+		sink, _ := g.FindNode(30)
+
+		d, err := g.Dinic(source, sink)
+		if err != nil {
+			t.Fatal("round", round, ": Dinic:", err)
+		}
+		p, err := g.PushRelabel(source, sink)
+		if err != nil {
+			t.Fatal("round", round, ": PushRelabel:", err)
+		}
+		if d.Value != p.Value {
+			t.Fatal("round", round, ": Dinic found", d.Value, "but PushRelabel found", p.Value)
+		}
+		checkFlow(t, g, 1, 30, d)
+		checkFlow(t, g, 1, 30, p)
+	}
+}
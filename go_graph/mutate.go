@@ -0,0 +1,258 @@
+package graph
+
+import "fmt"
+
+// EdgeNotFoundError is returned when removing an edge
+// that is not in the graph
+type EdgeNotFoundError struct {
+	From, To int
+}
+
+func (e *EdgeNotFoundError) Error() string {
+	return fmt.Sprintf("no edge from %v to %v", e.From, e.To)
+}
+
+// EdgeSpec describes an edge by the values of its ends.
+// In a directed graph the edge goes from From to To
+type EdgeSpec struct {
+	From   int
+	To     int
+	Weight int
+}
+
+// RemoveNode removes n and every edge touching it from the graph
+func (g *ItemGraph) RemoveNode(n *Node) error {
+	return g.RemoveNodes([]int{n.value})
+}
+
+// RemoveEdge removes the edge from n1 to n2 from the graph
+func (g *ItemGraph) RemoveEdge(n1, n2 *Node) error {
+	return g.RemoveEdges([]EdgeSpec{{From: n1.value, To: n2.value}})
+}
+
+// AddNodes adds a node for each of values. If any value is already in
+// the graph or repeated in values, it returns a *DuplicateNodeError
+// and leaves the graph unchanged
+func (g *ItemGraph) AddNodes(values []int) error {
+	return g.AddNodesAt(values, nil)
+}
+
+// AddNodesAt is like AddNodes, but places the nodes whose
+// values are keys of positions at the given points
+func (g *ItemGraph) AddNodesAt(values []int, positions map[int]Point) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if err := g.checkAddNodes(values); err != nil {
+		return err
+	}
+	for _, v := range values {
+		if p, ok := positions[v]; ok {
+			g.addNode(NewNodeAt(v, p))
+		} else {
+			g.addNode(NewNode(v))
+		}
+	}
+	return nil
+}
+
+// RemoveNodes removes the nodes with the given values along with every
+// edge touching them. If any value is not in the graph, it returns a
+// *NodeNotFoundError and leaves the graph unchanged
+func (g *ItemGraph) RemoveNodes(values []int) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if err := g.checkRemoveNodes(values); err != nil {
+		return err
+	}
+	for _, v := range values {
+		// The value may be repeated in values
+		if n, ok := g.index[v]; ok {
+			g.removeNode(n)
+		}
+	}
+	return nil
+}
+
+// AddEdges adds the given edges to the graph, replacing the weight of
+// those already in it. If an end of any edge is not in the graph, it
+// returns a *NodeNotFoundError and leaves the graph unchanged
+func (g *ItemGraph) AddEdges(edges []EdgeSpec) error {
+	return g.AddEdgesWithCapacity(edges, nil)
+}
+
+// AddEdgesWithCapacity is like AddEdges, but also sets the capacity of
+// each edge to the number at the same index of capacities. The edges
+// past the end of capacities get a capacity of 0
+func (g *ItemGraph) AddEdgesWithCapacity(edges []EdgeSpec, capacities []int) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if err := g.checkAddEdges(edges); err != nil {
+		return err
+	}
+	for i, e := range edges {
+		capacity := 0
+		if i < len(capacities) {
+			capacity = capacities[i]
+		}
+		g.addEdge(g.index[e.From], g.index[e.To], e.Weight, capacity)
+	}
+	return nil
+}
+
+// RemoveEdges removes the given edges from the graph, ignoring their
+// weights. If any edge is not in the graph, it returns a
+// *EdgeNotFoundError and leaves the graph unchanged
+func (g *ItemGraph) RemoveEdges(edges []EdgeSpec) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if err := g.checkRemoveEdges(edges); err != nil {
+		return err
+	}
+	for _, e := range edges {
+		g.removeEdge(g.index[e.From], g.index[e.To])
+	}
+	return nil
+}
+
+// CheckAddNodes returns the error that AddNodes would return for
+// values, without changing the graph
+func (g *ItemGraph) CheckAddNodes(values []int) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.checkAddNodes(values)
+}
+
+// CheckRemoveNodes returns the error that RemoveNodes would return
+// for values, without changing the graph
+func (g *ItemGraph) CheckRemoveNodes(values []int) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.checkRemoveNodes(values)
+}
+
+// CheckAddEdges returns the error that AddEdges would return for
+// edges, without changing the graph
+func (g *ItemGraph) CheckAddEdges(edges []EdgeSpec) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.checkAddEdges(edges)
+}
+
+// CheckRemoveEdges returns the error that RemoveEdges would return
+// for edges, without changing the graph
+func (g *ItemGraph) CheckRemoveEdges(edges []EdgeSpec) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.checkRemoveEdges(edges)
+}
+
+func (g *ItemGraph) checkAddNodes(values []int) error {
+	seen := make(map[int]bool)
+	for _, v := range values {
+		if _, ok := g.index[v]; ok || seen[v] {
+			return &DuplicateNodeError{Value: v}
+		}
+		seen[v] = true
+	}
+	return nil
+}
+
+func (g *ItemGraph) checkRemoveNodes(values []int) error {
+	for _, v := range values {
+		if _, ok := g.index[v]; !ok {
+			return &NodeNotFoundError{Value: v}
+		}
+	}
+	return nil
+}
+
+func (g *ItemGraph) checkAddEdges(edges []EdgeSpec) error {
+	for _, e := range edges {
+		for _, v := range []int{e.From, e.To} {
+			if _, ok := g.index[v]; !ok {
+				return &NodeNotFoundError{Value: v}
+			}
+		}
+	}
+	return nil
+}
+
+func (g *ItemGraph) checkRemoveEdges(edges []EdgeSpec) error {
+	for _, e := range edges {
+		n1, ok1 := g.index[e.From]
+		n2, ok2 := g.index[e.To]
+		if !ok1 || !ok2 || g.findEdge(n1, n2) == nil {
+			return &EdgeNotFoundError{From: e.From, To: e.To}
+		}
+	}
+	return nil
+}
+
+// removeNode drops n, its edges, and the edges pointing to it
+func (g *ItemGraph) removeNode(n *Node) {
+	for _, e := range g.edges[*n] {
+		if g.directed {
+			dropEdge(g.inEdges, e.Node, n)
+		} else {
+			dropEdge(g.edges, e.Node, n)
+		}
+		g.numEdges--
+		if e.Weight < 0 {
+			g.negEdges--
+		}
+	}
+	if g.directed {
+		for _, e := range g.inEdges[*n] {
+			dropEdge(g.edges, e.Node, n)
+			g.numEdges--
+			if e.Weight < 0 {
+				g.negEdges--
+			}
+		}
+	}
+
+	delete(g.edges, *n)
+	delete(g.inEdges, *n)
+	delete(g.index, n.value)
+	for i, m := range g.nodes {
+		if m == n {
+			g.nodes = append(g.nodes[:i:i], g.nodes[i+1:]...)
+			break
+		}
+	}
+}
+
+// removeEdge drops the edge from n1 to n2 from both of its ends
+func (g *ItemGraph) removeEdge(n1, n2 *Node) {
+	e := g.findEdge(n1, n2)
+	if e == nil {
+		return
+	}
+	dropEdge(g.edges, n1, n2)
+	g.numEdges--
+	if e.Weight < 0 {
+		g.negEdges--
+	}
+	if g.directed {
+		dropEdge(g.inEdges, n2, n1)
+	} else {
+		dropEdge(g.edges, n2, n1)
+	}
+}
+
+// dropEdge removes the edge to n2 stored under n1.
+// It tells whether there was such an edge
+func dropEdge(edges map[Node][]*Edge, n1, n2 *Node) bool {
+	near := edges[*n1]
+	for i, e := range near {
+		if e.Node == n2 {
+			edges[*n1] = append(near[:i:i], near[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
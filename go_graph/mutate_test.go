@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+// newTestGraph builds a graph with nodes 1..n and the given edges
+func newTestGraph(directed bool, n int, edges []EdgeSpec) *ItemGraph {
+	g := NewGraph()
+	if directed {
+		g = NewDirectedGraph()
+	}
+	for v := 1; v <= n; v++ {
+		g.AddNode(NewNode(v))
+	}
+	g.AddEdges(edges)
+	return g
+}
+
+// neighborValues lists the out- and in-neighbors of v
+func neighborValues(g *ItemGraph, v int) ([]int, []int) {
+	n, _ := g.FindNode(v)
+	return values(g.OutNeighbors(n)), values(g.InNeighbors(n))
+}
+
+// Test that removing nodes also removes the edges touching them
+func TestItemGraph_RemoveNodes(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		directed bool
+		out, in  []int
+		edges    int
+	}{
+		{"undirected", false, []int{3}, []int{3}, 1},
+		{"directed", true, nil, []int{3}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1 -> 2 -> 3 -> 1, 2 -> 2 and 4 -> 2
+			g := newTestGraph(tt.directed, 4, []EdgeSpec{
+				{From: 1, To: 2, Weight: 1}, {From: 2, To: 3, Weight: 1},
+				{From: 3, To: 1, Weight: 1}, {From: 2, To: 2, Weight: 1},
+				{From: 4, To: 2, Weight: 1},
+			})
+
+			if err := g.RemoveNodes([]int{2, 4}); err != nil {
+				t.Fatal("cannot remove nodes", err)
+			}
+
+			if _, err := g.FindNode(2); err == nil {
+				t.Error("found removed node")
+			}
+			if out, in := neighborValues(g, 1); !equal(out, tt.out) || !equal(in, tt.in) {
+				t.Error("neighbors of 1: expected", tt.out, tt.in, "received", out, in)
+			}
+			if g.NodeCount() != 2 || g.EdgeCount() != tt.edges {
+				t.Error("size: expected", 2, tt.edges, "received", g.NodeCount(), g.EdgeCount())
+			}
+		})
+	}
+}
+
+// Test that removing edges keeps both of their ends in sync
+func TestItemGraph_RemoveEdges(t *testing.T) {
+	g := newTestGraph(true, 3, []EdgeSpec{
+		{From: 1, To: 2, Weight: 1}, {From: 2, To: 1, Weight: 1}, {From: 1, To: 3, Weight: 1},
+	})
+
+	if err := g.RemoveEdges([]EdgeSpec{{From: 1, To: 2}}); err != nil {
+		t.Fatal("cannot remove edge", err)
+	}
+
+	if out, in := neighborValues(g, 1); !equal(out, []int{3}) || !equal(in, []int{2}) {
+		t.Error("neighbors of 1: expected", []int{3}, []int{2}, "received", out, in)
+	}
+	if out, in := neighborValues(g, 2); !equal(out, []int{1}) || in != nil {
+		t.Error("neighbors of 2: expected", []int{1}, "[] received", out, in)
+	}
+	if g.EdgeCount() != 2 {
+		t.Error("edges: expected", 2, "received", g.EdgeCount())
+	}
+}
+
+// Test that a batch with a bad element leaves the graph unchanged
+func TestItemGraph_AtomicBatches(t *testing.T) {
+	g := newTestGraph(false, 3, []EdgeSpec{{From: 1, To: 2, Weight: 1}})
+
+	var dup *DuplicateNodeError
+	if err := g.AddNodes([]int{4, 5, 4}); !errors.As(err, &dup) || dup.Value != 4 {
+		t.Error("add nodes: expected duplicate 4, received", err)
+	}
+
+	var missing *NodeNotFoundError
+	if err := g.RemoveNodes([]int{1, 9}); !errors.As(err, &missing) || missing.Value != 9 {
+		t.Error("remove nodes: expected missing 9, received", err)
+	}
+	if err := g.AddEdges([]EdgeSpec{{From: 2, To: 3}, {From: 3, To: 8}}); !errors.As(err, &missing) || missing.Value != 8 {
+		t.Error("add edges: expected missing 8, received", err)
+	}
+
+	var noEdge *EdgeNotFoundError
+	if err := g.RemoveEdges([]EdgeSpec{{From: 2, To: 1}, {From: 1, To: 3}}); !errors.As(err, &noEdge) || noEdge.To != 3 {
+		t.Error("remove edges: expected missing 1-3, received", err)
+	}
+
+	if g.NodeCount() != 3 || g.EdgeCount() != 1 {
+		t.Error("size: expected", 3, 1, "received", g.NodeCount(), g.EdgeCount())
+	}
+}
@@ -1,72 +1,95 @@
 package graph
 
-import "sync"
+import "container/heap"
 
+// Vertex is a node waiting in the queue with its tentative distance
+type Vertex struct {
+	Node     *Node
+	Distance int
+
+	// Position of the vertex in the heap, maintained by PriorityQueue
+	index int
+}
+
+// PriorityQueue is a binary min-heap of vertices ordered by distance.
+// It implements heap.Interface and keeps every vertex's index up to date
 type PriorityQueue []*Vertex
 
+func (pq PriorityQueue) Len() int { return len(pq) }
+
+func (pq PriorityQueue) Less(i, j int) bool {
+	return pq[i].Distance < pq[j].Distance
+}
+
+func (pq PriorityQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].index = i
+	pq[j].index = j
+}
+
+func (pq *PriorityQueue) Push(x interface{}) {
+	v := x.(*Vertex)
+	v.index = len(*pq)
+	*pq = append(*pq, v)
+}
+
+func (pq *PriorityQueue) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	v := old[n-1]
+	old[n-1] = nil
+	v.index = -1
+	*pq = old[:n-1]
+	return v
+}
+
+// NodeQueue is an indexed priority queue of nodes.
+// A node is held at most once: enqueuing it again with a shorter
+// distance decreases its key in place. The queue is meant to be
+// local to one search and is not safe for concurrent use
 type NodeQueue struct {
-	Items []Vertex
-	Lock  sync.RWMutex
+	items PriorityQueue
+	index map[int]*Vertex
 }
 
-// Enqueue adds an Node to the end of the queue
+// NewNodeQueue creates an empty queue
+func NewNodeQueue() *NodeQueue {
+	return &NodeQueue{index: make(map[int]*Vertex)}
+}
+
+// Enqueue adds a node to the queue. If the node is already queued,
+// its distance is lowered to t.Distance when that is shorter
 func (s *NodeQueue) Enqueue(t Vertex) {
-	s.Lock.Lock()
-	if len(s.Items) == 0 {
-		s.Items = append(s.Items, t)
-		s.Lock.Unlock()
-		return
-	}
-	var insertFlag bool
-	for k, v := range s.Items {
+	if v, ok := s.index[t.Node.Value()]; ok {
 		if t.Distance < v.Distance {
-			if k > 0 {
-				s.Items = append(s.Items[:k+1], s.Items[k:]...)
-				s.Items[k] = t
-				insertFlag = true
-			} else {
-				s.Items = append([]Vertex{t}, s.Items...)
-				insertFlag = true
-			}
-		}
-		if insertFlag {
-			break
+			v.Distance = t.Distance
+			heap.Fix(&s.items, v.index)
 		}
+		return
 	}
-	if !insertFlag {
-		s.Items = append(s.Items, t)
-	}
-	//s.items = append(s.items, t)
-	s.Lock.Unlock()
+	v := &Vertex{Node: t.Node, Distance: t.Distance}
+	s.index[t.Node.Value()] = v
+	heap.Push(&s.items, v)
 }
 
-// Dequeue removes an Node from the start of the queue
+// Dequeue removes the node with the shortest distance from the queue
 func (s *NodeQueue) Dequeue() *Vertex {
-	s.Lock.Lock()
-	item := s.Items[0]
-	s.Items = s.Items[1:len(s.Items)]
-	s.Lock.Unlock()
-	return &item
+	v := heap.Pop(&s.items).(*Vertex)
+	delete(s.index, v.Node.Value())
+	return v
 }
 
-//NewQ Creates New Queue
-func (s *NodeQueue) NewQ() *NodeQueue {
-	s.Lock.Lock()
-	s.Items = []Vertex{}
-	s.Lock.Unlock()
-	return s
+// min returns the shortest distance in the queue, which must not be empty
+func (s *NodeQueue) min() int {
+	return s.items[0].Distance
 }
 
 // IsEmpty returns true if the queue is empty
 func (s *NodeQueue) IsEmpty() bool {
-	s.Lock.RLock()
-	defer s.Lock.RUnlock()
-	return len(s.Items) == 0
+	return len(s.items) == 0
 }
 
 // Size returns the number of Nodes in the queue
 func (s *NodeQueue) Size() int {
-	s.Lock.RLock()
-	defer s.Lock.RUnlock()
-	return len(s.Items)
+	return len(s.items)
 }
@@ -0,0 +1,165 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Test that vertices leave the queue by distance, and that
+// enqueuing a queued node again only ever lowers its distance
+func TestNodeQueue_DecreaseKey(t *testing.T) {
+	n := []*Node{NewNode(0), NewNode(1), NewNode(2), NewNode(3)}
+
+	q := NewNodeQueue()
+	q.Enqueue(Vertex{Node: n[0], Distance: 5})
+	q.Enqueue(Vertex{Node: n[1], Distance: 3})
+	q.Enqueue(Vertex{Node: n[2], Distance: 8})
+	q.Enqueue(Vertex{Node: n[3], Distance: 4})
+
+	// Decrease the key of 2, and try to increase the key of 1
+	q.Enqueue(Vertex{Node: n[2], Distance: 1})
+	q.Enqueue(Vertex{Node: n[1], Distance: 9})
+
+	if q.Size() != 4 {
+		t.Error("size: expected", 4, "received", q.Size())
+	}
+
+	expected := []Vertex{{Node: n[2], Distance: 1}, {Node: n[1], Distance: 3},
+		{Node: n[3], Distance: 4}, {Node: n[0], Distance: 5}}
+	for _, e := range expected {
+		v := q.Dequeue()
+		if v.Node != e.Node || v.Distance != e.Distance {
+			t.Error("dequeue: expected", e.Node, e.Distance, "received", v.Node, v.Distance)
+		}
+	}
+
+	if !q.IsEmpty() {
+		t.Error("queue is not empty after dequeuing every node")
+	}
+}
+
+// queue is what a search needs from its priority queue
+type queue interface {
+	Enqueue(Vertex)
+	Dequeue() *Vertex
+	IsEmpty() bool
+}
+
+// sliceQueue is the sorted-slice queue that NodeQueue replaced,
+// kept here as a baseline for the benchmarks
+type sliceQueue struct {
+	items []Vertex
+}
+
+func (s *sliceQueue) Enqueue(t Vertex) {
+	for k, v := range s.items {
+		if t.Distance < v.Distance {
+			s.items = append(s.items[:k+1], s.items[k:]...)
+			s.items[k] = t
+			return
+		}
+	}
+	s.items = append(s.items, t)
+}
+
+func (s *sliceQueue) Dequeue() *Vertex {
+	item := s.items[0]
+	s.items = s.items[1:]
+	return &item
+}
+
+func (s *sliceQueue) IsEmpty() bool {
+	return len(s.items) == 0
+}
+
+// randomGraph builds a connected sparse graph of n nodes: a ring,
+// plus two random chords per node, with edge weights between 1 and 100
+func randomGraph(n int) (*ItemGraph, []*Node) {
+	r := rand.New(rand.NewSource(1))
+	g := NewGraph()
+	nodes := make([]*Node, n)
+	for i := range nodes {
+		nodes[i] = NewNode(i)
+		g.AddNode(nodes[i])
+	}
+	for i := range nodes {
+		g.AddWeightedEdge(nodes[i], nodes[(i+1)%n], 1+r.Intn(100))
+		for j := 0; j < 2; j++ {
+			g.AddWeightedEdge(nodes[i], nodes[r.Intn(n)], 1+r.Intn(100))
+		}
+	}
+	return g, nodes
+}
+
+// distances runs Dijkstra's algorithm from start with the given queue
+func distances(g *ItemGraph, start *Node, pq queue) map[int]int {
+	dist := make(map[int]int)
+	visited := make(map[int]bool)
+	for _, n := range g.nodes {
+		dist[n.Value()] = math.MaxInt64
+	}
+	dist[start.Value()] = 0
+	pq.Enqueue(Vertex{Node: start, Distance: 0})
+
+	for !pq.IsEmpty() {
+		v := pq.Dequeue()
+		if visited[v.Node.Value()] {
+			continue
+		}
+		visited[v.Node.Value()] = true
+		for _, e := range g.edges[*v.Node] {
+			if d := dist[v.Node.Value()] + e.Weight; d < dist[e.Node.Value()] {
+				dist[e.Node.Value()] = d
+				pq.Enqueue(Vertex{Node: e.Node, Distance: d})
+			}
+		}
+	}
+	return dist
+}
+
+// Test that both queues lead to the same distances
+func TestNodeQueue_MatchesSliceQueue(t *testing.T) {
+	g, nodes := randomGraph(1000)
+
+	expected := distances(g, nodes[0], &sliceQueue{})
+	received := distances(g, nodes[0], NewNodeQueue())
+
+	for _, n := range nodes {
+		if expected[n.Value()] != received[n.Value()] {
+			t.Error("distance to", n, ": expected", expected[n.Value()], "received", received[n.Value()])
+		}
+	}
+}
+
+// Dijkstra's algorithm on a graph with 100,000 nodes,
+// using the sorted-slice queue
+func BenchmarkDijkstra_SliceQueue(b *testing.B) {
+	g, nodes := randomGraph(100000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		distances(g, nodes[0], &sliceQueue{})
+	}
+}
+
+// Dijkstra's algorithm on a graph with 100,000 nodes,
+// using the binary heap
+func BenchmarkDijkstra_NodeQueue(b *testing.B) {
+	g, nodes := randomGraph(100000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		distances(g, nodes[0], NewNodeQueue())
+	}
+}
+
+// GetShortestPath on a graph with 100,000 nodes
+func BenchmarkItemGraph_GetShortestPath(b *testing.B) {
+	g, nodes := randomGraph(100000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		g.GetShortestPath(nodes[0], nodes[len(nodes)/2])
+	}
+}
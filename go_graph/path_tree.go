@@ -0,0 +1,134 @@
+package graph
+
+import "sort"
+
+// PathTree holds the shortest paths from one source node to every node
+// reachable from it, as found by a single run of Dijkstra's algorithm
+type PathTree struct {
+	source int
+
+	// The cost of the shortest path to each reachable node
+	dist map[int]int
+
+	// The node before each reachable node on its shortest path.
+	// The source has none
+	prev map[int]int
+}
+
+// ShortestPathTree finds the shortest paths from source to every node
+// reachable from it. Edge weights must be non-negative
+func (g *ItemGraph) ShortestPathTree(source *Node) *PathTree {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.shortestPathTree(source)
+}
+
+func (g *ItemGraph) shortestPathTree(source *Node) *PathTree {
+	t := &PathTree{
+		source: source.value,
+		dist:   make(map[int]int),
+		prev:   make(map[int]int),
+	}
+
+	// The best distance found so far to nodes not yet settled
+	best := map[int]int{source.value: 0}
+
+	pq := NewNodeQueue()
+	pq.Enqueue(Vertex{Node: source, Distance: 0})
+	for !pq.IsEmpty() {
+		v := pq.Dequeue()
+		t.dist[v.Node.value] = v.Distance
+		delete(best, v.Node.value)
+
+		for _, e := range g.edges[*v.Node] {
+			u := e.Node.value
+			if _, settled := t.dist[u]; settled {
+				continue
+			}
+			d := v.Distance + e.Weight
+			if b, ok := best[u]; !ok || d < b {
+				best[u] = d
+				t.prev[u] = v.Node.value
+				pq.Enqueue(Vertex{Node: e.Node, Distance: d})
+			}
+		}
+	}
+	return t
+}
+
+// TreeEntry is a node reached by a PathTree
+type TreeEntry struct {
+	Value int
+
+	// The cost of the shortest path to the node
+	Distance int
+
+	// The node before this one on its shortest path.
+	// The source is its own parent
+	Parent int
+}
+
+// Source returns the value of the node the paths start from
+func (t *PathTree) Source() int {
+	return t.source
+}
+
+// Reachable tells whether the node with value v can be reached
+// from the source
+func (t *PathTree) Reachable(v int) bool {
+	_, ok := t.dist[v]
+	return ok
+}
+
+// PathTo returns the shortest path from the source to the node with
+// value v, along with its cost, or ErrNoPath if v cannot be reached
+func (t *PathTree) PathTo(v int) ([]int, int, error) {
+	cost, ok := t.dist[v]
+	if !ok {
+		return nil, 0, ErrNoPath
+	}
+
+	return tracePath(t.prev, t.source, v), cost, nil
+}
+
+// Len returns the number of nodes reachable from the source,
+// counting the source itself
+func (t *PathTree) Len() int {
+	return len(t.dist)
+}
+
+// Distance returns the cost of the shortest path from the source to
+// the node with value v, and whether v can be reached
+func (t *PathTree) Distance(v int) (int, bool) {
+	d, ok := t.dist[v]
+	return d, ok
+}
+
+// Parent returns the node before the one with value v on its shortest
+// path, and whether v can be reached. The source is its own parent
+func (t *PathTree) Parent(v int) (int, bool) {
+	if _, ok := t.dist[v]; !ok {
+		return 0, false
+	}
+	if v == t.source {
+		return v, true
+	}
+	return t.prev[v], true
+}
+
+// Entries returns every node reached by the tree, in order of distance
+// from the source and then of value
+func (t *PathTree) Entries() []TreeEntry {
+	entries := make([]TreeEntry, 0, len(t.dist))
+	for v, d := range t.dist {
+		p, _ := t.Parent(v)
+		entries = append(entries, TreeEntry{Value: v, Distance: d, Parent: p})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Distance != entries[j].Distance {
+			return entries[i].Distance < entries[j].Distance
+		}
+		return entries[i].Value < entries[j].Value
+	})
+	return entries
+}
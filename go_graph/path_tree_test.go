@@ -0,0 +1,95 @@
+package graph
+
+import "testing"
+
+// Test that the tree gives the shortest path to every node,
+// and reports the nodes it cannot reach
+func TestItemGraph_ShortestPathTree(t *testing.T) {
+	g, nodes := randomGraph(200)
+	island := NewNode(-1)
+	g.AddNode(island)
+
+	tree := g.ShortestPathTree(nodes[0])
+	want := distances(g, nodes[0], &sliceQueue{})
+
+	if tree.Source() != 0 {
+		t.Error("source: expected", 0, "received", tree.Source())
+	}
+	if tree.Len() != len(nodes) {
+		t.Error("reachable nodes: expected", len(nodes), "received", tree.Len())
+	}
+
+	for _, n := range nodes {
+		path, cost, err := tree.PathTo(n.Value())
+		if err != nil {
+			t.Fatal("path to", n, ":", err)
+		}
+		if cost != want[n.Value()] {
+			t.Error("cost to", n, ": expected", want[n.Value()], "received", cost)
+		}
+		if path[0] != 0 || path[len(path)-1] != n.Value() {
+			t.Error("path to", n, ": received", path)
+		}
+
+		// The cost must be that of the edges along the path
+		sum := 0
+		for i := 1; i < len(path); i++ {
+			a, _ := g.FindNode(path[i-1])
+			b, _ := g.FindNode(path[i])
+			e := g.findEdge(a, b)
+			if e == nil {
+				t.Fatal("path to", n, ": no edge from", a, "to", b)
+			}
+			sum += e.Weight
+		}
+		if sum != cost {
+			t.Error("path to", n, ": edges sum to", sum, "but cost is", cost)
+		}
+	}
+
+	if tree.Reachable(island.Value()) {
+		t.Error("expected", island, "to be unreachable")
+	}
+	if _, _, err := tree.PathTo(island.Value()); err != ErrNoPath {
+		t.Error("path to", island, ": expected", ErrNoPath, "received", err)
+	}
+}
+
+// Test the entries of a tree, in order of distance and then value
+func TestPathTree_Entries(t *testing.T) {
+	g := newTestGraph(true, 5, []EdgeSpec{
+		{From: 1, To: 2, Weight: 3},
+		{From: 1, To: 3, Weight: 1},
+		{From: 3, To: 2, Weight: 1},
+		{From: 2, To: 4, Weight: 4},
+		{From: 4, To: 1, Weight: 1},
+	})
+	source, _ := g.FindNode(1)
+	tree := g.ShortestPathTree(source)
+
+	want := []TreeEntry{
+		{Value: 1, Distance: 0, Parent: 1},
+		{Value: 3, Distance: 1, Parent: 1},
+		{Value: 2, Distance: 2, Parent: 3},
+		{Value: 4, Distance: 6, Parent: 2},
+	}
+	got := tree.Entries()
+	if len(got) != len(want) {
+		t.Fatal("entries: expected", want, "received", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Error("entry", i, ": expected", want[i], "received", got[i])
+		}
+	}
+
+	if _, ok := tree.Distance(5); ok {
+		t.Error("expected 5 to be unreachable")
+	}
+	if _, ok := tree.Parent(5); ok {
+		t.Error("expected 5 to have no parent")
+	}
+	if d, _ := tree.Distance(4); d != 6 {
+		t.Error("distance to 4: expected", 6, "received", d)
+	}
+}
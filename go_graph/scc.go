@@ -0,0 +1,161 @@
+package graph
+
+import "sort"
+
+// StronglyConnectedComponents finds the strongly connected components of
+// the graph as it is when called, with Tarjan's algorithm in O(V + E)
+// time. Two nodes are in the same component when each can be reached
+// from the other. The components are numbered in topological order of
+// the condensation: every edge between two components goes from a lower
+// number to a higher one. In an undirected graph, they are the
+// connected components
+func (g *ItemGraph) StronglyConnectedComponents() *Components {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.stronglyConnectedComponents()
+}
+
+func (g *ItemGraph) stronglyConnectedComponents() *Components {
+	// Number the nodes in ascending order of value, so that
+	// the search, and the numbering it gives, are the same
+	// for the same graph
+	values := make([]int, len(g.nodes))
+	for i, n := range g.nodes {
+		values[i] = n.value
+	}
+	sort.Ints(values)
+	index := make(map[int]int, len(values))
+	for i, v := range values {
+		index[v] = i
+	}
+	adj := make([][]int, len(values))
+	for _, n := range g.nodes {
+		i := index[n.value]
+		for _, e := range g.edges[*n] {
+			adj[i] = append(adj[i], index[e.Node.value])
+		}
+	}
+
+	// The order each node was reached in, counting from 1, and the
+	// lowest order reachable from it through the nodes on the stack
+	order := make([]int, len(values))
+	low := make([]int, len(values))
+	onStack := make([]bool, len(values))
+	var stack []int
+	count := 0
+
+	// The depth-first search keeps its own call stack, so that long
+	// paths cannot overflow the goroutine's. Each frame is a node and
+	// the next of its edges to follow
+	type frame struct{ v, next int }
+	var calls []frame
+
+	// Tarjan's algorithm closes the components sinks first
+	var found [][]int
+	visit := func(v int) {
+		count++
+		order[v], low[v] = count, count
+		stack = append(stack, v)
+		onStack[v] = true
+		calls = append(calls, frame{v: v})
+	}
+	for s := range values {
+		if order[s] != 0 {
+			continue
+		}
+		visit(s)
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			v := f.v
+			if f.next < len(adj[v]) {
+				w := adj[v][f.next]
+				f.next++
+				if order[w] == 0 {
+					visit(w)
+				} else if onStack[w] && order[w] < low[v] {
+					low[v] = order[w]
+				}
+				continue
+			}
+
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				if u := calls[len(calls)-1].v; low[v] < low[u] {
+					low[u] = low[v]
+				}
+			}
+			if low[v] == order[v] {
+				var members []int
+				for {
+					w := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[w] = false
+					members = append(members, values[w])
+					if w == v {
+						break
+					}
+				}
+				sort.Ints(members)
+				found = append(found, members)
+			}
+		}
+	}
+
+	c := &Components{label: make(map[int]int, len(values))}
+	for k := len(found) - 1; k >= 0; k-- {
+		for _, v := range found[k] {
+			c.label[v] = len(c.members)
+		}
+		c.members = append(c.members, found[k])
+	}
+	return c
+}
+
+// Condensation returns the strongly connected components of the graph,
+// and the directed graph with a node for each of them, whose value is
+// the number of the component. It has an edge from one component to
+// another when the graph has an edge from a node of the first to a node
+// of the second, weighing as much as the lightest such edge. It has no
+// cycle, and its nodes are in topological order
+func (g *ItemGraph) Condensation() (*Components, *ItemGraph) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	c := g.stronglyConnectedComponents()
+	dag := NewDirectedGraph()
+	nodes := make([]*Node, c.Count())
+	for k := range nodes {
+		nodes[k] = NewNode(k)
+		dag.addNode(nodes[k])
+	}
+
+	// The lightest edge between each pair of components
+	weights := make(map[[2]int]int)
+	var pairs [][2]int
+	for _, n := range g.nodes {
+		for _, e := range g.edges[*n] {
+			k1, k2 := c.label[n.value], c.label[e.Node.value]
+			if k1 == k2 {
+				continue
+			}
+			pair := [2]int{k1, k2}
+			w, ok := weights[pair]
+			if !ok {
+				pairs = append(pairs, pair)
+			}
+			if !ok || e.Weight < w {
+				weights[pair] = e.Weight
+			}
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	for _, p := range pairs {
+		dag.addEdge(nodes[p[0]], nodes[p[1]], weights[p], 0)
+	}
+	return c, dag
+}
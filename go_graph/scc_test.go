@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Test the strongly connected components of a few graphs, and that
+// they are numbered in topological order
+func TestItemGraph_StronglyConnectedComponents(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		g       *ItemGraph
+		members [][]int
+	}{
+		{
+			"two cycles joined",
+			newTestGraph(true, 7, []EdgeSpec{
+				{1, 2, 1}, {2, 3, 1}, {3, 1, 1}, {3, 4, 1},
+				{4, 5, 1}, {5, 4, 1}, {6, 5, 1}, {7, 7, 1},
+			}),
+			[][]int{{7}, {6}, {1, 2, 3}, {4, 5}},
+		},
+		{
+			"chain",
+			newTestGraph(true, 4, []EdgeSpec{{4, 3, 1}, {3, 2, 1}, {2, 1, 1}}),
+			[][]int{{4}, {3}, {2}, {1}},
+		},
+		{
+			"undirected",
+			newTestGraph(false, 4, []EdgeSpec{{1, 2, 1}, {3, 4, 1}}),
+			[][]int{{3, 4}, {1, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.g.StronglyConnectedComponents()
+			if c.Count() != len(tt.members) {
+				t.Fatal("count: expected", len(tt.members), "received", c.Count())
+			}
+			for k, want := range tt.members {
+				if got := c.Members(k); !equal(got, want) {
+					t.Error("component", k, ": expected", want, "received", got)
+				}
+			}
+		})
+	}
+}
+
+// Test that nodes share a component exactly when each reaches the
+// other, and that the condensation is a DAG in topological order with
+// the lightest edges between components
+func TestItemGraph_Condensation(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	g := NewDirectedGraph()
+	nodes := make([]*Node, 60)
+	for i := range nodes {
+		nodes[i] = NewNode(i)
+		g.AddNode(nodes[i])
+	}
+	for k := 0; k < 90; k++ {
+		g.AddWeightedEdge(nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))], 1+r.Intn(9))
+	}
+
+	c, dag := g.Condensation()
+	for _, u := range nodes {
+		for _, v := range nodes {
+			strong := g.Reachable(u, v) && g.Reachable(v, u)
+			ku, _ := c.Label(u.Value())
+			kv, _ := c.Label(v.Value())
+			if strong != (ku == kv) {
+				t.Fatal(u, "and", v, ": expected together", strong, "received components", ku, kv)
+			}
+		}
+	}
+
+	if dag.NodeCount() != c.Count() || !dag.Directed() {
+		t.Fatal("condensation: expected", c.Count(), "directed nodes, received", dag.NodeCount())
+	}
+	lightest := make(map[[2]int]int)
+	for _, e := range g.Edges() {
+		k1, _ := c.Label(e.From)
+		k2, _ := c.Label(e.To)
+		if w, ok := lightest[[2]int{k1, k2}]; k1 != k2 && (!ok || e.Weight < w) {
+			lightest[[2]int{k1, k2}] = e.Weight
+		}
+	}
+	for _, e := range dag.Edges() {
+		if e.From >= e.To {
+			t.Error("edge", e, ": expected to go up the topological order")
+		}
+		if w := lightest[[2]int{e.From, e.To}]; w != e.Weight {
+			t.Error("edge", e, ": expected weight", w)
+		}
+	}
+	if dag.EdgeCount() != len(lightest) {
+		t.Error("edges: expected", len(lightest), "received", dag.EdgeCount())
+	}
+}
+
+// A long chain, which would take a deep recursion
+func TestItemGraph_StronglyConnectedComponentsDeep(t *testing.T) {
+	g := NewDirectedGraph()
+	prev := NewNode(0)
+	g.AddNode(prev)
+	for i := 1; i < 200000; i++ {
+		n := NewNode(i)
+		g.AddNode(n)
+		g.AddEdge(prev, n)
+		prev = n
+	}
+	first, _ := g.FindNode(0)
+	g.AddEdge(prev, first)
+
+	if c := g.StronglyConnectedComponents(); c.Count() != 1 {
+		t.Error("count: expected 1, received", c.Count())
+	}
+}
@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrDirected is returned by the algorithms that only apply
+// to undirected graphs
+var ErrDirected = errors.New("graph is directed")
+
+// Kruskal finds a minimum spanning forest of an undirected graph: for
+// each connected component, a tree of edges joining all its nodes with
+// the least total weight. It adds the edges in ascending order of weight,
+// skipping those whose ends a union-find forest shows to be joined
+// already, in O(E log E) time. It returns the edges of the forest in
+// that order, each from its end of lower value, and their total weight.
+// It returns ErrDirected for a directed graph
+func (g *ItemGraph) Kruskal() ([]EdgeSpec, int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+
+	index := make(map[int]int, len(g.nodes))
+	for i, n := range g.nodes {
+		index[n.value] = i
+	}
+	edges := make([]EdgeSpec, 0, g.numEdges)
+	for _, n := range g.nodes {
+		for _, e := range g.edges[*n] {
+			if n.value < e.Node.value {
+				edges = append(edges, EdgeSpec{From: n.value, To: e.Node.value, Weight: e.Weight})
+			}
+		}
+	}
+	// Break ties by the ends, so that the forest is the
+	// same for the same graph
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.Weight != b.Weight {
+			return a.Weight < b.Weight
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+
+	uf := newUnionFind(len(g.nodes))
+	var forest []EdgeSpec
+	total := 0
+	for _, e := range edges {
+		if uf.union(index[e.From], index[e.To]) {
+			forest = append(forest, e)
+			total += e.Weight
+		}
+	}
+	return forest, total, nil
+}
+
+// Prim finds a minimum spanning forest of an undirected graph, like
+// Kruskal, by growing a tree from the node of lowest value in each
+// component, always by the lightest edge leaving it, in O(E log V)
+// time. It returns the edges of the forest in the order they were
+// added, each from its end of lower value, and their total weight.
+// It returns ErrDirected for a directed graph
+func (g *ItemGraph) Prim() ([]EdgeSpec, int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+
+	roots := append([]*Node(nil), g.nodes...)
+	sort.Slice(roots, func(i, j int) bool { return roots[i].value < roots[j].value })
+
+	inTree := make(map[int]bool, len(g.nodes))
+
+	// The lightest edge found so far from the tree to each node
+	// not in it, by the value of its end in the tree
+	parent := make(map[int]int)
+	weight := make(map[int]int)
+
+	var forest []EdgeSpec
+	total := 0
+	for _, root := range roots {
+		if inTree[root.value] {
+			continue
+		}
+		pq := NewNodeQueue()
+		pq.Enqueue(Vertex{Node: root, Distance: 0})
+		for !pq.IsEmpty() {
+			v := pq.Dequeue().Node
+			inTree[v.value] = true
+			if v != root {
+				u := parent[v.value]
+				e := EdgeSpec{From: u, To: v.value, Weight: weight[v.value]}
+				if e.From > e.To {
+					e.From, e.To = e.To, e.From
+				}
+				forest = append(forest, e)
+				total += e.Weight
+			}
+
+			for _, e := range g.edges[*v] {
+				u := e.Node.value
+				if inTree[u] {
+					continue
+				}
+				if w, ok := weight[u]; !ok || e.Weight < w {
+					weight[u] = e.Weight
+					parent[u] = v.value
+					pq.Enqueue(Vertex{Node: e.Node, Distance: e.Weight})
+				}
+			}
+		}
+	}
+	return forest, total, nil
+}
@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Test both algorithms on a small graph with two components
+func TestItemGraph_MinimumSpanningForest(t *testing.T) {
+	// A square 1-2-3-4 with a diagonal, and a separate edge 5-6.
+	// 7 is alone
+	g := newTestGraph(false, 7, []EdgeSpec{
+		{1, 2, 4}, {2, 3, 1}, {3, 4, 3}, {4, 1, 2}, {1, 3, 5},
+		{5, 6, -2},
+	})
+
+	tests := []struct {
+		name string
+		mst  func() ([]EdgeSpec, int, error)
+		want []EdgeSpec
+	}{
+		{"Kruskal", g.Kruskal, []EdgeSpec{{5, 6, -2}, {2, 3, 1}, {1, 4, 2}, {3, 4, 3}}},
+		{"Prim", g.Prim, []EdgeSpec{{1, 4, 2}, {3, 4, 3}, {2, 3, 1}, {5, 6, -2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edges, total, err := tt.mst()
+			if err != nil || total != 4 || len(edges) != len(tt.want) {
+				t.Fatal("expected", tt.want, "of weight 4, received", edges, total, err)
+			}
+			for i, e := range edges {
+				if e != tt.want[i] {
+					t.Error("edge", i, ": expected", tt.want[i], "received", e)
+				}
+			}
+		})
+	}
+
+	d := newTestGraph(true, 2, []EdgeSpec{{1, 2, 1}})
+	if _, _, err := d.Kruskal(); err != ErrDirected {
+		t.Error("Kruskal, directed: expected", ErrDirected, "received", err)
+	}
+	if _, _, err := d.Prim(); err != ErrDirected {
+		t.Error("Prim, directed: expected", ErrDirected, "received", err)
+	}
+}
+
+// Test that both algorithms find forests of the same weight on random
+// graphs, that span every component without a cycle
+func TestItemGraph_MinimumSpanningForestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	for round := 0; round < 20; round++ {
+		g := NewGraph()
+		nodes := make([]*Node, 50)
+		for i := range nodes {
+			nodes[i] = NewNode(i)
+			g.AddNode(nodes[i])
+		}
+		for k := 0; k < 60; k++ {
+			g.AddWeightedEdge(nodes[r.Intn(len(nodes))], nodes[r.Intn(len(nodes))], r.Intn(20))
+		}
+		components := g.ConnectedComponents().Count()
+
+		kEdges, kTotal, _ := g.Kruskal()
+		pEdges, pTotal, _ := g.Prim()
+		if kTotal != pTotal {
+			t.Fatal("round", round, ": Kruskal weighs", kTotal, "but Prim weighs", pTotal)
+		}
+		for _, edges := range [][]EdgeSpec{kEdges, pEdges} {
+			// A forest spanning every component has
+			// one edge fewer than nodes per component
+			if len(edges) != len(nodes)-components {
+				t.Fatal("round", round, ": expected", len(nodes)-components, "edges, received", len(edges))
+			}
+			forest := NewGraph()
+			for _, n := range nodes {
+				forest.AddNode(NewNode(n.Value()))
+			}
+			forest.AddEdges(edges)
+			if c := forest.ConnectedComponents().Count(); c != components {
+				t.Fatal("round", round, ": expected", components, "components, received", c)
+			}
+			for _, e := range edges {
+				n1, _ := g.FindNode(e.From)
+				n2, _ := g.FindNode(e.To)
+				if !g.HasEdge(n1, n2) {
+					t.Fatal("round", round, ": edge", e, "is not in the graph")
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrCycle is returned when a directed graph has a cycle, so that its
+// nodes have no topological order
+var ErrCycle = errors.New("graph has a cycle")
+
+// ErrUndirected is returned by the algorithms that only apply
+// to directed graphs
+var ErrUndirected = errors.New("graph is not directed")
+
+// CycleError is returned when a directed graph has a cycle where an
+// acyclic graph is needed. It matches ErrCycle with errors.Is
+type CycleError struct {
+	// The values of the nodes around the cycle, in the direction
+	// of its edges. The first node is not repeated at the end
+	Cycle []int
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("cycle through %v", e.Cycle)
+}
+
+func (e *CycleError) Is(target error) bool {
+	return target == ErrCycle
+}
+
+// TopologicalSort orders the nodes of a directed graph so that every
+// edge goes from a node to a later one, with Kahn's algorithm. Among
+// the nodes that could come next, the lowest value comes first, so the
+// order is the same for the same graph. It returns a *CycleError if
+// the graph has a cycle, and ErrUndirected for an undirected graph
+func (g *ItemGraph) TopologicalSort() ([]int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+
+	indegree := g.indegrees()
+	ready := &intHeap{}
+	for _, n := range g.nodes {
+		if indegree[n.value] == 0 {
+			*ready = append(*ready, n.value)
+		}
+	}
+	heap.Init(ready)
+
+	order := make([]int, 0, len(g.nodes))
+	for ready.Len() > 0 {
+		v := heap.Pop(ready).(int)
+		order = append(order, v)
+		for _, e := range g.edges[*g.index[v]] {
+			if indegree[e.Node.value]--; indegree[e.Node.value] == 0 {
+				heap.Push(ready, e.Node.value)
+			}
+		}
+	}
+	if len(order) < len(g.nodes) {
+		return nil, &CycleError{Cycle: g.findCycle()}
+	}
+	return order, nil
+}
+
+// TopologicalLayers groups the nodes of a directed graph into layers,
+// so that every edge goes from a node to one in a later layer. A node
+// is in the first layer after all the nodes it depends on, so the nodes
+// of a layer can be processed in parallel once the layers before it
+// are done. Each layer is in ascending order. It returns a *CycleError
+// if the graph has a cycle, and ErrUndirected for an undirected graph
+func (g *ItemGraph) TopologicalLayers() ([][]int, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+
+	indegree := g.indegrees()
+	var layer []int
+	for _, n := range g.nodes {
+		if indegree[n.value] == 0 {
+			layer = append(layer, n.value)
+		}
+	}
+
+	var layers [][]int
+	placed := 0
+	for len(layer) > 0 {
+		sort.Ints(layer)
+		layers = append(layers, layer)
+		placed += len(layer)
+
+		var next []int
+		for _, v := range layer {
+			for _, e := range g.edges[*g.index[v]] {
+				if indegree[e.Node.value]--; indegree[e.Node.value] == 0 {
+					next = append(next, e.Node.value)
+				}
+			}
+		}
+		layer = next
+	}
+	if placed < len(g.nodes) {
+		return nil, &CycleError{Cycle: g.findCycle()}
+	}
+	return layers, nil
+}
+
+// FindCycle returns the values of the nodes around a cycle of a directed
+// graph, in the direction of its edges, or nil if it has none. It also
+// returns nil for an undirected graph
+func (g *ItemGraph) FindCycle() []int {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	if !g.directed {
+		return nil
+	}
+	return g.findCycle()
+}
+
+// findCycle runs a depth-first search until it follows an edge back
+// to a node on the current path, which closes a cycle
+func (g *ItemGraph) findCycle() []int {
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[int]int, len(g.nodes))
+
+	// The search keeps its own call stack, so that long paths
+	// cannot overflow the goroutine's. Each frame is a node and
+	// the next of its edges to follow
+	type frame struct {
+		n    *Node
+		next int
+	}
+	for _, root := range g.nodes {
+		if state[root.value] != unvisited {
+			continue
+		}
+		state[root.value] = onPath
+		path := []frame{{n: root}}
+		for len(path) > 0 {
+			f := &path[len(path)-1]
+			edges := g.edges[*f.n]
+			if f.next == len(edges) {
+				state[f.n.value] = done
+				path = path[:len(path)-1]
+				continue
+			}
+			e := edges[f.next]
+			f.next++
+
+			switch state[e.Node.value] {
+			case unvisited:
+				state[e.Node.value] = onPath
+				path = append(path, frame{n: e.Node})
+			case onPath:
+				// The cycle is the path from that node on
+				var cycle []int
+				for i := len(path) - 1; i >= 0; i-- {
+					cycle = append(cycle, path[i].n.value)
+					if path[i].n == e.Node {
+						break
+					}
+				}
+				for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+					cycle[i], cycle[j] = cycle[j], cycle[i]
+				}
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// indegrees counts the edges entering each node of a directed graph
+func (g *ItemGraph) indegrees() map[int]int {
+	indegree := make(map[int]int, len(g.nodes))
+	for _, n := range g.nodes {
+		indegree[n.value] = len(g.inEdges[*n])
+	}
+	return indegree
+}
+
+// intHeap is a min-heap of ints
+type intHeap []int
+
+func (h intHeap) Len() int            { return len(h) }
+func (h intHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
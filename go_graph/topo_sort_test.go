@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// Test the order and layers of a small dependency graph
+func TestItemGraph_TopologicalSort(t *testing.T) {
+	// 1 and 2 come first, then 3 needs both, and 4 and 5 need 3.
+	// 6 needs nothing, and 5 also needs 6
+	g := newTestGraph(true, 6, []EdgeSpec{
+		{1, 3, 1}, {2, 3, 1}, {3, 4, 1}, {3, 5, 1}, {6, 5, 1},
+	})
+
+	order, err := g.TopologicalSort()
+	if want := []int{1, 2, 3, 4, 6, 5}; err != nil || !equal(order, want) {
+		t.Error("order: expected", want, "received", order, err)
+	}
+
+	layers, err := g.TopologicalLayers()
+	want := [][]int{{1, 2, 6}, {3}, {4, 5}}
+	if err != nil || len(layers) != len(want) {
+		t.Fatal("layers: expected", want, "received", layers, err)
+	}
+	for i := range want {
+		if !equal(layers[i], want[i]) {
+			t.Error("layer", i, ": expected", want[i], "received", layers[i])
+		}
+	}
+
+	if _, err := NewGraph().TopologicalSort(); err != ErrUndirected {
+		t.Error("undirected: expected", ErrUndirected, "received", err)
+	}
+}
+
+// Test that every edge of a random DAG goes forward in the order and
+// across the layers, and that a cycle added to it is reported
+func TestItemGraph_TopologicalSortRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	g := NewDirectedGraph()
+	nodes := make([]*Node, 80)
+	for i := range nodes {
+		nodes[i] = NewNode(r.Intn(1000)*100 + i)
+		g.AddNode(nodes[i])
+	}
+	// Edges only go from a node to one added later
+	for k := 0; k < 200; k++ {
+		i, j := r.Intn(len(nodes)), r.Intn(len(nodes))
+		if i < j {
+			g.AddEdge(nodes[i], nodes[j])
+		}
+	}
+
+	order, err := g.TopologicalSort()
+	if err != nil || len(order) != len(nodes) {
+		t.Fatal("order: expected all", len(nodes), "nodes, received", order, err)
+	}
+	layers, err := g.TopologicalLayers()
+	if err != nil {
+		t.Fatal("layers:", err)
+	}
+	position := make(map[int]int)
+	layer := make(map[int]int)
+	for i, v := range order {
+		position[v] = i
+	}
+	for i, l := range layers {
+		for _, v := range l {
+			layer[v] = i
+		}
+	}
+	for _, e := range g.Edges() {
+		if position[e.From] >= position[e.To] || layer[e.From] >= layer[e.To] {
+			t.Fatal("edge", e, "goes back in the order or the layers")
+		}
+	}
+	if c := g.FindCycle(); c != nil {
+		t.Error("cycle: expected none, received", c)
+	}
+
+	// Close a cycle from the last node in the order to the first
+	// node it can be reached from
+	last, _ := g.FindNode(order[len(order)-1])
+	first := last
+	for _, v := range order {
+		if n, _ := g.FindNode(v); g.Reachable(n, last) {
+			first = n
+			break
+		}
+	}
+	g.AddEdge(last, first)
+
+	for _, f := range []func() error{
+		func() error { _, err := g.TopologicalSort(); return err },
+		func() error { _, err := g.TopologicalLayers(); return err },
+	} {
+		err := f()
+		var cycle *CycleError
+		if !errors.As(err, &cycle) || !errors.Is(err, ErrCycle) {
+			t.Fatal("error: expected a cycle, received", err)
+		}
+		for i, v := range cycle.Cycle {
+			n1, _ := g.FindNode(v)
+			n2, _ := g.FindNode(cycle.Cycle[(i+1)%len(cycle.Cycle)])
+			if !g.HasEdge(n1, n2) {
+				t.Fatal("cycle", cycle.Cycle, ": no edge from", v)
+			}
+		}
+	}
+}
+
+// Test that a self-loop is a cycle, and that long chains are searched
+// without a deep recursion
+func TestItemGraph_FindCycle(t *testing.T) {
+	g := newTestGraph(true, 3, []EdgeSpec{{1, 2, 1}, {2, 2, 1}})
+	if c := g.FindCycle(); !equal(c, []int{2}) {
+		t.Error("self-loop: expected [2], received", c)
+	}
+
+	g = NewDirectedGraph()
+	prev := NewNode(0)
+	g.AddNode(prev)
+	for i := 1; i < 200000; i++ {
+		n := NewNode(i)
+		g.AddNode(n)
+		g.AddEdge(prev, n)
+		prev = n
+	}
+	if c := g.FindCycle(); c != nil {
+		t.Error("chain: expected no cycle, received", len(c), "nodes")
+	}
+	first, _ := g.FindNode(0)
+	g.AddEdge(prev, first)
+	if c := g.FindCycle(); len(c) != 200000 || c[0] != 0 {
+		t.Error("ring: expected 200000 nodes from 0, received", len(c))
+	}
+}
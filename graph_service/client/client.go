@@ -67,7 +67,7 @@ func main() {
 		for _, n := range path.Path {
 			fmt.Printf("%v ", n)
 		}
-		fmt.Println()
+		fmt.Printf("(cost %v)\n", path.Cost)
 	}
 
 	log.Printf("Deleting graph %v", id.Id)
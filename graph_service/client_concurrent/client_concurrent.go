@@ -110,7 +110,7 @@ func main() {
 			path, err := c.ShortestPath(ctx, req)
 
 			if err != nil {
-				log.Fatalf("could find shortest graph (id = %d): %v", gid, err)
+				log.Fatalf("could find shortest graph (id = %d): %v", gid.Id, err)
 			}
 
 			compQueue <- QueryResult{queryID, path, err}
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.0
+// 	protoc-gen-go v1.28.1
 // 	protoc        v3.19.4
 // source: graph.proto
 
@@ -9,6 +9,8 @@ package graphservice
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,7 +22,389 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type GraphID struct {
+// The algorithm finding a shortest path
+type PathAlgorithm int32
+
+const (
+	// A query of the contraction hierarchy of the graph, once it is
+	// prepared, and bidirectional Dijkstra until then. Paths from a
+	// source already cached by DIJKSTRA are read off the cache
+	PathAlgorithm_PATH_AUTO PathAlgorithm = 0
+	// Dijkstra's algorithm from the source to every vertex. The
+	// paths found are cached for later queries from the same source
+	PathAlgorithm_DIJKSTRA PathAlgorithm = 1
+	PathAlgorithm_A_STAR   PathAlgorithm = 2
+	// Dijkstra's algorithm from both ends of the path at once
+	PathAlgorithm_BIDIRECTIONAL PathAlgorithm = 3
+	// Handles negative weights, and is used whatever the requested
+	// algorithm on graphs that have any
+	PathAlgorithm_BELLMAN_FORD PathAlgorithm = 4
+	// A* search bounded by the distances to a few landmark vertices,
+	// once the graph is prepared with LANDMARKS, and Dijkstra's
+	// algorithm stopping at the end of the path until then
+	PathAlgorithm_ALT PathAlgorithm = 5
+)
+
+// Enum value maps for PathAlgorithm.
+var (
+	PathAlgorithm_name = map[int32]string{
+		0: "PATH_AUTO",
+		1: "DIJKSTRA",
+		2: "A_STAR",
+		3: "BIDIRECTIONAL",
+		4: "BELLMAN_FORD",
+		5: "ALT",
+	}
+	PathAlgorithm_value = map[string]int32{
+		"PATH_AUTO":     0,
+		"DIJKSTRA":      1,
+		"A_STAR":        2,
+		"BIDIRECTIONAL": 3,
+		"BELLMAN_FORD":  4,
+		"ALT":           5,
+	}
+)
+
+func (x PathAlgorithm) Enum() *PathAlgorithm {
+	p := new(PathAlgorithm)
+	*p = x
+	return p
+}
+
+func (x PathAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PathAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_graph_proto_enumTypes[0].Descriptor()
+}
+
+func (PathAlgorithm) Type() protoreflect.EnumType {
+	return &file_graph_proto_enumTypes[0]
+}
+
+func (x PathAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PathAlgorithm.Descriptor instead.
+func (PathAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{0}
+}
+
+// How A* estimates the distance left to the end of a path, from the
+// positions of the vertices. The estimate is scaled down to the lowest
+// weight per distance of the edges, so that it is never too high
+type PathHeuristic int32
+
+const (
+	// The straight-line distance between x/y positions
+	PathHeuristic_EUCLIDEAN PathHeuristic = 0
+	// The great-circle distance between longitude/latitude positions
+	PathHeuristic_HAVERSINE PathHeuristic = 1
+)
+
+// Enum value maps for PathHeuristic.
+var (
+	PathHeuristic_name = map[int32]string{
+		0: "EUCLIDEAN",
+		1: "HAVERSINE",
+	}
+	PathHeuristic_value = map[string]int32{
+		"EUCLIDEAN": 0,
+		"HAVERSINE": 1,
+	}
+)
+
+func (x PathHeuristic) Enum() *PathHeuristic {
+	p := new(PathHeuristic)
+	*p = x
+	return p
+}
+
+func (x PathHeuristic) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PathHeuristic) Descriptor() protoreflect.EnumDescriptor {
+	return file_graph_proto_enumTypes[1].Descriptor()
+}
+
+func (PathHeuristic) Type() protoreflect.EnumType {
+	return &file_graph_proto_enumTypes[1]
+}
+
+func (x PathHeuristic) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PathHeuristic.Descriptor instead.
+func (PathHeuristic) EnumDescriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{1}
+}
+
+// The algorithm computing all-pairs shortest paths
+type AllPairsAlgorithm int32
+
+const (
+	// Johnson's algorithm, unless the graph is dense and
+	// small enough for Floyd-Warshall
+	AllPairsAlgorithm_ALL_PAIRS_AUTO AllPairsAlgorithm = 0
+	// Holds the whole distance matrix, so it is limited
+	// to graphs of 2000 vertices
+	AllPairsAlgorithm_FLOYD_WARSHALL AllPairsAlgorithm = 1
+	// Finds each row just before it is sent
+	AllPairsAlgorithm_JOHNSON AllPairsAlgorithm = 2
+)
+
+// Enum value maps for AllPairsAlgorithm.
+var (
+	AllPairsAlgorithm_name = map[int32]string{
+		0: "ALL_PAIRS_AUTO",
+		1: "FLOYD_WARSHALL",
+		2: "JOHNSON",
+	}
+	AllPairsAlgorithm_value = map[string]int32{
+		"ALL_PAIRS_AUTO": 0,
+		"FLOYD_WARSHALL": 1,
+		"JOHNSON":        2,
+	}
+)
+
+func (x AllPairsAlgorithm) Enum() *AllPairsAlgorithm {
+	p := new(AllPairsAlgorithm)
+	*p = x
+	return p
+}
+
+func (x AllPairsAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AllPairsAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_graph_proto_enumTypes[2].Descriptor()
+}
+
+func (AllPairsAlgorithm) Type() protoreflect.EnumType {
+	return &file_graph_proto_enumTypes[2]
+}
+
+func (x AllPairsAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AllPairsAlgorithm.Descriptor instead.
+func (AllPairsAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{2}
+}
+
+// The preprocessing of a graph
+type PrepareMode int32
+
+const (
+	PrepareMode_PREPARE_UNSPECIFIED PrepareMode = 0
+	// A contraction hierarchy: the vertices are ranked and shortcut
+	// edges are added, so that queries only search up the ranks
+	PrepareMode_CH PrepareMode = 1
+	// Landmarks: the distances between a few vertices and all the
+	// others, which bound the cost left to the end of a path.
+	// Used by ALT queries
+	PrepareMode_LANDMARKS PrepareMode = 2
+)
+
+// Enum value maps for PrepareMode.
+var (
+	PrepareMode_name = map[int32]string{
+		0: "PREPARE_UNSPECIFIED",
+		1: "CH",
+		2: "LANDMARKS",
+	}
+	PrepareMode_value = map[string]int32{
+		"PREPARE_UNSPECIFIED": 0,
+		"CH":                  1,
+		"LANDMARKS":           2,
+	}
+)
+
+func (x PrepareMode) Enum() *PrepareMode {
+	p := new(PrepareMode)
+	*p = x
+	return p
+}
+
+func (x PrepareMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PrepareMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_graph_proto_enumTypes[3].Descriptor()
+}
+
+func (PrepareMode) Type() protoreflect.EnumType {
+	return &file_graph_proto_enumTypes[3]
+}
+
+func (x PrepareMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PrepareMode.Descriptor instead.
+func (PrepareMode) EnumDescriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{3}
+}
+
+// How the landmarks of a LANDMARKS preparation are picked
+type LandmarkSelection int32
+
+const (
+	// Each as far as possible from those picked before it
+	LandmarkSelection_FARTHEST LandmarkSelection = 0
+	LandmarkSelection_RANDOM   LandmarkSelection = 1
+)
+
+// Enum value maps for LandmarkSelection.
+var (
+	LandmarkSelection_name = map[int32]string{
+		0: "FARTHEST",
+		1: "RANDOM",
+	}
+	LandmarkSelection_value = map[string]int32{
+		"FARTHEST": 0,
+		"RANDOM":   1,
+	}
+)
+
+func (x LandmarkSelection) Enum() *LandmarkSelection {
+	p := new(LandmarkSelection)
+	*p = x
+	return p
+}
+
+func (x LandmarkSelection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LandmarkSelection) Descriptor() protoreflect.EnumDescriptor {
+	return file_graph_proto_enumTypes[4].Descriptor()
+}
+
+func (LandmarkSelection) Type() protoreflect.EnumType {
+	return &file_graph_proto_enumTypes[4]
+}
+
+func (x LandmarkSelection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LandmarkSelection.Descriptor instead.
+func (LandmarkSelection) EnumDescriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{4}
+}
+
+// The algorithm finding a minimum spanning forest. Both find forests
+// of the same weight, but may pick different edges of equal weight
+type MSTAlgorithm int32
+
+const (
+	// Adds the lightest edges first, unless they close a cycle
+	MSTAlgorithm_KRUSKAL MSTAlgorithm = 0
+	// Grows a tree from one vertex of each component by the
+	// lightest edge leaving it
+	MSTAlgorithm_PRIM MSTAlgorithm = 1
+)
+
+// Enum value maps for MSTAlgorithm.
+var (
+	MSTAlgorithm_name = map[int32]string{
+		0: "KRUSKAL",
+		1: "PRIM",
+	}
+	MSTAlgorithm_value = map[string]int32{
+		"KRUSKAL": 0,
+		"PRIM":    1,
+	}
+)
+
+func (x MSTAlgorithm) Enum() *MSTAlgorithm {
+	p := new(MSTAlgorithm)
+	*p = x
+	return p
+}
+
+func (x MSTAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MSTAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_graph_proto_enumTypes[5].Descriptor()
+}
+
+func (MSTAlgorithm) Type() protoreflect.EnumType {
+	return &file_graph_proto_enumTypes[5]
+}
+
+func (x MSTAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MSTAlgorithm.Descriptor instead.
+func (MSTAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{5}
+}
+
+// The algorithm finding a maximum flow. Both find flows of the same
+// value, but may route them differently
+type FlowAlgorithm int32
+
+const (
+	// Saturates the shortest paths with spare capacity,
+	// shortest first
+	FlowAlgorithm_DINIC FlowAlgorithm = 0
+	// Floods the edges of the source, and pushes the excess of each
+	// vertex on toward the sink, or back to the source
+	FlowAlgorithm_PUSH_RELABEL FlowAlgorithm = 1
+)
+
+// Enum value maps for FlowAlgorithm.
+var (
+	FlowAlgorithm_name = map[int32]string{
+		0: "DINIC",
+		1: "PUSH_RELABEL",
+	}
+	FlowAlgorithm_value = map[string]int32{
+		"DINIC":        0,
+		"PUSH_RELABEL": 1,
+	}
+)
+
+func (x FlowAlgorithm) Enum() *FlowAlgorithm {
+	p := new(FlowAlgorithm)
+	*p = x
+	return p
+}
+
+func (x FlowAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FlowAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_graph_proto_enumTypes[6].Descriptor()
+}
+
+func (FlowAlgorithm) Type() protoreflect.EnumType {
+	return &file_graph_proto_enumTypes[6]
+}
+
+func (x FlowAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FlowAlgorithm.Descriptor instead.
+func (FlowAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{6}
+}
+
+type Vertex struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -28,8 +412,8 @@ type GraphID struct {
 	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *GraphID) Reset() {
-	*x = GraphID{}
+func (x *Vertex) Reset() {
+	*x = Vertex{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_graph_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -37,13 +421,13 @@ func (x *GraphID) Reset() {
 	}
 }
 
-func (x *GraphID) String() string {
+func (x *Vertex) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GraphID) ProtoMessage() {}
+func (*Vertex) ProtoMessage() {}
 
-func (x *GraphID) ProtoReflect() protoreflect.Message {
+func (x *Vertex) ProtoReflect() protoreflect.Message {
 	mi := &file_graph_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -55,28 +439,28 @@ func (x *GraphID) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GraphID.ProtoReflect.Descriptor instead.
-func (*GraphID) Descriptor() ([]byte, []int) {
+// Deprecated: Use Vertex.ProtoReflect.Descriptor instead.
+func (*Vertex) Descriptor() ([]byte, []int) {
 	return file_graph_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *GraphID) GetId() int32 {
+func (x *Vertex) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type Neighbors struct {
+type GraphID struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Neighbors []int32 `protobuf:"varint,1,rep,packed,name=neighbors,proto3" json:"neighbors,omitempty"`
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *Neighbors) Reset() {
-	*x = Neighbors{}
+func (x *GraphID) Reset() {
+	*x = GraphID{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_graph_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -84,13 +468,13 @@ func (x *Neighbors) Reset() {
 	}
 }
 
-func (x *Neighbors) String() string {
+func (x *GraphID) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Neighbors) ProtoMessage() {}
+func (*GraphID) ProtoMessage() {}
 
-func (x *Neighbors) ProtoReflect() protoreflect.Message {
+func (x *GraphID) ProtoReflect() protoreflect.Message {
 	mi := &file_graph_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -102,29 +486,33 @@ func (x *Neighbors) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Neighbors.ProtoReflect.Descriptor instead.
-func (*Neighbors) Descriptor() ([]byte, []int) {
+// Deprecated: Use GraphID.ProtoReflect.Descriptor instead.
+func (*GraphID) Descriptor() ([]byte, []int) {
 	return file_graph_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Neighbors) GetNeighbors() []int32 {
+func (x *GraphID) GetId() int32 {
 	if x != nil {
-		return x.Neighbors
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type Graph struct {
+type Edge struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Vertices []int32              `protobuf:"varint,1,rep,packed,name=vertices,proto3" json:"vertices,omitempty"`
-	Edges    map[int32]*Neighbors `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	V1     *Vertex `protobuf:"bytes,1,opt,name=v1,proto3" json:"v1,omitempty"`
+	V2     *Vertex `protobuf:"bytes,2,opt,name=v2,proto3" json:"v2,omitempty"`
+	Weight int32   `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	// The most flow the edge can carry, for MaxFlow. An undirected
+	// edge carries it either way. Edges without one carry none
+	Capacity int32 `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`
 }
 
-func (x *Graph) Reset() {
-	*x = Graph{}
+func (x *Edge) Reset() {
+	*x = Edge{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_graph_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -132,13 +520,13 @@ func (x *Graph) Reset() {
 	}
 }
 
-func (x *Graph) String() string {
+func (x *Edge) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Graph) ProtoMessage() {}
+func (*Edge) ProtoMessage() {}
 
-func (x *Graph) ProtoReflect() protoreflect.Message {
+func (x *Edge) ProtoReflect() protoreflect.Message {
 	mi := &file_graph_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -150,37 +538,49 @@ func (x *Graph) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Graph.ProtoReflect.Descriptor instead.
-func (*Graph) Descriptor() ([]byte, []int) {
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
 	return file_graph_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Graph) GetVertices() []int32 {
+func (x *Edge) GetV1() *Vertex {
 	if x != nil {
-		return x.Vertices
+		return x.V1
 	}
 	return nil
 }
 
-func (x *Graph) GetEdges() map[int32]*Neighbors {
+func (x *Edge) GetV2() *Vertex {
 	if x != nil {
-		return x.Edges
+		return x.V2
 	}
 	return nil
 }
 
-type PathRequest struct {
+func (x *Edge) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Edge) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type Neighbors struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Gid *GraphID `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
-	S   int32    `protobuf:"varint,2,opt,name=s,proto3" json:"s,omitempty"`
-	T   int32    `protobuf:"varint,3,opt,name=t,proto3" json:"t,omitempty"`
+	Neighbors []int32 `protobuf:"varint,1,rep,packed,name=neighbors,proto3" json:"neighbors,omitempty"`
 }
 
-func (x *PathRequest) Reset() {
-	*x = PathRequest{}
+func (x *Neighbors) Reset() {
+	*x = Neighbors{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_graph_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -188,13 +588,13 @@ func (x *PathRequest) Reset() {
 	}
 }
 
-func (x *PathRequest) String() string {
+func (x *Neighbors) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PathRequest) ProtoMessage() {}
+func (*Neighbors) ProtoMessage() {}
 
-func (x *PathRequest) ProtoReflect() protoreflect.Message {
+func (x *Neighbors) ProtoReflect() protoreflect.Message {
 	mi := &file_graph_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -206,42 +606,31 @@ func (x *PathRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PathRequest.ProtoReflect.Descriptor instead.
-func (*PathRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Neighbors.ProtoReflect.Descriptor instead.
+func (*Neighbors) Descriptor() ([]byte, []int) {
 	return file_graph_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *PathRequest) GetGid() *GraphID {
+func (x *Neighbors) GetNeighbors() []int32 {
 	if x != nil {
-		return x.Gid
+		return x.Neighbors
 	}
 	return nil
 }
 
-func (x *PathRequest) GetS() int32 {
-	if x != nil {
-		return x.S
-	}
-	return 0
-}
-
-func (x *PathRequest) GetT() int32 {
-	if x != nil {
-		return x.T
-	}
-	return 0
-}
-
-type Path struct {
+// Where a vertex is placed: x and y, or the longitude and the
+// latitude in degrees for a geographic graph
+type Point struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Path []int32 `protobuf:"varint,1,rep,packed,name=path,proto3" json:"path,omitempty"`
+	X float64 `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y float64 `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
 }
 
-func (x *Path) Reset() {
-	*x = Path{}
+func (x *Point) Reset() {
+	*x = Point{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_graph_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -249,13 +638,13 @@ func (x *Path) Reset() {
 	}
 }
 
-func (x *Path) String() string {
+func (x *Point) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Path) ProtoMessage() {}
+func (*Point) ProtoMessage() {}
 
-func (x *Path) ProtoReflect() protoreflect.Message {
+func (x *Point) ProtoReflect() protoreflect.Message {
 	mi := &file_graph_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -267,28 +656,46 @@ func (x *Path) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Path.ProtoReflect.Descriptor instead.
-func (*Path) Descriptor() ([]byte, []int) {
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
 	return file_graph_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Path) GetPath() []int32 {
+func (x *Point) GetX() float64 {
 	if x != nil {
-		return x.Path
+		return x.X
 	}
-	return nil
+	return 0
 }
 
-type DeleteReply struct {
+func (x *Point) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type Graph struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Vertices []int32 `protobuf:"varint,1,rep,packed,name=vertices,proto3" json:"vertices,omitempty"`
+	// Unweighted edges, each of weight 1
+	Edges map[int32]*Neighbors `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Edges with an explicit weight, which may only be
+	// negative in a directed graph
+	WeightedEdges []*Edge `protobuf:"bytes,3,rep,name=weighted_edges,json=weightedEdges,proto3" json:"weighted_edges,omitempty"`
+	// When set, every edge goes one way only: from the vertex of a
+	// [Neighbors] entry to its neighbors, and from v1 to v2 of an [Edge]
+	Directed bool `protobuf:"varint,4,opt,name=directed,proto3" json:"directed,omitempty"`
+	// The positions of the vertices, by ID, which let A* search
+	// toward the end of a path. Any vertex may be left out
+	Positions map[int32]*Point `protobuf:"bytes,5,rep,name=positions,proto3" json:"positions,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *DeleteReply) Reset() {
-	*x = DeleteReply{}
+func (x *Graph) Reset() {
+	*x = Graph{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_graph_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -296,131 +703,3508 @@ func (x *DeleteReply) Reset() {
 	}
 }
 
-func (x *DeleteReply) String() string {
+func (x *Graph) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteReply) ProtoMessage() {}
+func (*Graph) ProtoMessage() {}
 
-func (x *DeleteReply) ProtoReflect() protoreflect.Message {
+func (x *Graph) ProtoReflect() protoreflect.Message {
 	mi := &file_graph_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use DeleteReply.ProtoReflect.Descriptor instead.
-func (*DeleteReply) Descriptor() ([]byte, []int) {
-	return file_graph_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *DeleteReply) GetResult() string {
-	if x != nil {
-		return x.Result
-	}
-	return ""
-}
-
-var File_graph_proto protoreflect.FileDescriptor
-
-var file_graph_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x67,
-	0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0x19, 0x0a, 0x07, 0x47,
-	0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x29, 0x0a, 0x09, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62,
-	0x6f, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72,
-	0x73, 0x22, 0xac, 0x01, 0x0a, 0x05, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x76,
-	0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x76,
-	0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x45, 0x64, 0x67, 0x65,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x1a, 0x51, 0x0a,
-	0x0a, 0x45, 0x64, 0x67, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
-	0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4e, 0x65, 0x69, 0x67,
-	0x68, 0x62, 0x6f, 0x72, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0x52, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67,
-	0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70,
-	0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x01, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x01, 0x74, 0x22, 0x1a, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
-	0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x22, 0x25, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x32, 0xcd, 0x01, 0x0a, 0x0c, 0x47, 0x72, 0x61, 0x70,
-	0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x09, 0x50, 0x6f, 0x73, 0x74,
-	0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x13, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x1a, 0x15, 0x2e, 0x67, 0x72, 0x61,
-	0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49,
-	0x44, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0c, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x73, 0x74, 0x50,
-	0x61, 0x74, 0x68, 0x12, 0x19, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
-	0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x61,
-	0x74, 0x68, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x72,
-	0x61, 0x70, 0x68, 0x12, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x19, 0x2e, 0x67, 0x72, 0x61,
-	0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x79, 0x63, 0x32, 0x34, 0x35, 0x34, 0x2f, 0x47, 0x72, 0x61,
-	0x70, 0x68, 0x2d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_graph_proto_rawDescOnce sync.Once
-	file_graph_proto_rawDescData = file_graph_proto_rawDesc
-)
-
-func file_graph_proto_rawDescGZIP() []byte {
-	file_graph_proto_rawDescOnce.Do(func() {
-		file_graph_proto_rawDescData = protoimpl.X.CompressGZIP(file_graph_proto_rawDescData)
-	})
-	return file_graph_proto_rawDescData
-}
-
-var file_graph_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_graph_proto_goTypes = []interface{}{
-	(*GraphID)(nil),     // 0: graphservice.GraphID
-	(*Neighbors)(nil),   // 1: graphservice.Neighbors
-	(*Graph)(nil),       // 2: graphservice.Graph
-	(*PathRequest)(nil), // 3: graphservice.PathRequest
-	(*Path)(nil),        // 4: graphservice.Path
-	(*DeleteReply)(nil), // 5: graphservice.DeleteReply
-	nil,                 // 6: graphservice.Graph.EdgesEntry
-}
-var file_graph_proto_depIdxs = []int32{
-	6, // 0: graphservice.Graph.edges:type_name -> graphservice.Graph.EdgesEntry
-	0, // 1: graphservice.PathRequest.gid:type_name -> graphservice.GraphID
-	1, // 2: graphservice.Graph.EdgesEntry.value:type_name -> graphservice.Neighbors
-	2, // 3: graphservice.GraphService.PostGraph:input_type -> graphservice.Graph
-	3, // 4: graphservice.GraphService.ShortestPath:input_type -> graphservice.PathRequest
-	0, // 5: graphservice.GraphService.DeleteGraph:input_type -> graphservice.GraphID
-	0, // 6: graphservice.GraphService.PostGraph:output_type -> graphservice.GraphID
-	4, // 7: graphservice.GraphService.ShortestPath:output_type -> graphservice.Path
-	5, // 8: graphservice.GraphService.DeleteGraph:output_type -> graphservice.DeleteReply
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
-}
-
-func init() { file_graph_proto_init() }
-func file_graph_proto_init() {
-	if File_graph_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_graph_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GraphID); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Graph.ProtoReflect.Descriptor instead.
+func (*Graph) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Graph) GetVertices() []int32 {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+func (x *Graph) GetEdges() map[int32]*Neighbors {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *Graph) GetWeightedEdges() []*Edge {
+	if x != nil {
+		return x.WeightedEdges
+	}
+	return nil
+}
+
+func (x *Graph) GetDirected() bool {
+	if x != nil {
+		return x.Directed
+	}
+	return false
+}
+
+func (x *Graph) GetPositions() map[int32]*Point {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+type PathRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid       *GraphID      `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	S         int32         `protobuf:"varint,2,opt,name=s,proto3" json:"s,omitempty"`
+	T         int32         `protobuf:"varint,3,opt,name=t,proto3" json:"t,omitempty"`
+	Algorithm PathAlgorithm `protobuf:"varint,4,opt,name=algorithm,proto3,enum=graphservice.PathAlgorithm" json:"algorithm,omitempty"`
+	// Only used by A_STAR
+	Heuristic PathHeuristic `protobuf:"varint,5,opt,name=heuristic,proto3,enum=graphservice.PathHeuristic" json:"heuristic,omitempty"`
+}
+
+func (x *PathRequest) Reset() {
+	*x = PathRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathRequest) ProtoMessage() {}
+
+func (x *PathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathRequest.ProtoReflect.Descriptor instead.
+func (*PathRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PathRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *PathRequest) GetS() int32 {
+	if x != nil {
+		return x.S
+	}
+	return 0
+}
+
+func (x *PathRequest) GetT() int32 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+func (x *PathRequest) GetAlgorithm() PathAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return PathAlgorithm_PATH_AUTO
+}
+
+func (x *PathRequest) GetHeuristic() PathHeuristic {
+	if x != nil {
+		return x.Heuristic
+	}
+	return PathHeuristic_EUCLIDEAN
+}
+
+// Error detail of a FailedPrecondition error, for a search that
+// reached a cycle of negative total weight
+type NegativeCycle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid *GraphID `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	// The vertices around the cycle, in the direction of its edges
+	Vertices []int32 `protobuf:"varint,2,rep,packed,name=vertices,proto3" json:"vertices,omitempty"`
+}
+
+func (x *NegativeCycle) Reset() {
+	*x = NegativeCycle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NegativeCycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NegativeCycle) ProtoMessage() {}
+
+func (x *NegativeCycle) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NegativeCycle.ProtoReflect.Descriptor instead.
+func (*NegativeCycle) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NegativeCycle) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *NegativeCycle) GetVertices() []int32 {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+type Path struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path []int32 `protobuf:"varint,1,rep,packed,name=path,proto3" json:"path,omitempty"`
+	// Total weight of the edges along the path
+	Cost int64 `protobuf:"varint,2,opt,name=cost,proto3" json:"cost,omitempty"`
+	// The number of vertices the search settled, to compare the
+	// algorithms by. It is 0 when the path was read off cached paths
+	Settled int32 `protobuf:"varint,3,opt,name=settled,proto3" json:"settled,omitempty"`
+}
+
+func (x *Path) Reset() {
+	*x = Path{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Path) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Path) ProtoMessage() {}
+
+func (x *Path) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Path.ProtoReflect.Descriptor instead.
+func (*Path) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Path) GetPath() []int32 {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *Path) GetCost() int64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *Path) GetSettled() int32 {
+	if x != nil {
+		return x.Settled
+	}
+	return 0
+}
+
+type KPathsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid *GraphID `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	S   int32    `protobuf:"varint,2,opt,name=s,proto3" json:"s,omitempty"`
+	T   int32    `protobuf:"varint,3,opt,name=t,proto3" json:"t,omitempty"`
+	// The number of paths to find
+	K int32 `protobuf:"varint,4,opt,name=k,proto3" json:"k,omitempty"`
+}
+
+func (x *KPathsRequest) Reset() {
+	*x = KPathsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KPathsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KPathsRequest) ProtoMessage() {}
+
+func (x *KPathsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KPathsRequest.ProtoReflect.Descriptor instead.
+func (*KPathsRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *KPathsRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *KPathsRequest) GetS() int32 {
+	if x != nil {
+		return x.S
+	}
+	return 0
+}
+
+func (x *KPathsRequest) GetT() int32 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+func (x *KPathsRequest) GetK() int32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+// Paths in order of cost. There may be fewer than requested
+type PathList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Paths []*Path `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+}
+
+func (x *PathList) Reset() {
+	*x = PathList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathList) ProtoMessage() {}
+
+func (x *PathList) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathList.ProtoReflect.Descriptor instead.
+func (*PathList) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PathList) GetPaths() []*Path {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+type VertexPair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	S int32 `protobuf:"varint,1,opt,name=s,proto3" json:"s,omitempty"`
+	T int32 `protobuf:"varint,2,opt,name=t,proto3" json:"t,omitempty"`
+}
+
+func (x *VertexPair) Reset() {
+	*x = VertexPair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VertexPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VertexPair) ProtoMessage() {}
+
+func (x *VertexPair) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VertexPair.ProtoReflect.Descriptor instead.
+func (*VertexPair) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VertexPair) GetS() int32 {
+	if x != nil {
+		return x.S
+	}
+	return 0
+}
+
+func (x *VertexPair) GetT() int32 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+type BatchPathRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid   *GraphID      `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Pairs []*VertexPair `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
+}
+
+func (x *BatchPathRequest) Reset() {
+	*x = BatchPathRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchPathRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPathRequest) ProtoMessage() {}
+
+func (x *BatchPathRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPathRequest.ProtoReflect.Descriptor instead.
+func (*BatchPathRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchPathRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *BatchPathRequest) GetPairs() []*VertexPair {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+// Why no path was found for a pair of a batch
+type PathError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The gRPC status code ShortestPath would fail with
+	Code    int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The error details ShortestPath would attach, such as the
+	// ErrorInfo of a missing path or the ResourceInfo of a
+	// missing vertex
+	Details []*anypb.Any `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty"`
+}
+
+func (x *PathError) Reset() {
+	*x = PathError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathError) ProtoMessage() {}
+
+func (x *PathError) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathError.ProtoReflect.Descriptor instead.
+func (*PathError) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PathError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *PathError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PathError) GetDetails() []*anypb.Any {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type PathResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*PathResult_Path
+	//	*PathResult_Error
+	Result isPathResult_Result `protobuf_oneof:"result"`
+}
+
+func (x *PathResult) Reset() {
+	*x = PathResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathResult) ProtoMessage() {}
+
+func (x *PathResult) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathResult.ProtoReflect.Descriptor instead.
+func (*PathResult) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{14}
+}
+
+func (m *PathResult) GetResult() isPathResult_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *PathResult) GetPath() *Path {
+	if x, ok := x.GetResult().(*PathResult_Path); ok {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *PathResult) GetError() *PathError {
+	if x, ok := x.GetResult().(*PathResult_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isPathResult_Result interface {
+	isPathResult_Result()
+}
+
+type PathResult_Path struct {
+	Path *Path `protobuf:"bytes,1,opt,name=path,proto3,oneof"`
+}
+
+type PathResult_Error struct {
+	Error *PathError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*PathResult_Path) isPathResult_Result() {}
+
+func (*PathResult_Error) isPathResult_Result() {}
+
+// The results of a batch, in the order of its pairs
+type BatchPathReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*PathResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *BatchPathReply) Reset() {
+	*x = BatchPathReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchPathReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPathReply) ProtoMessage() {}
+
+func (x *BatchPathReply) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPathReply.ProtoReflect.Descriptor instead.
+func (*BatchPathReply) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BatchPathReply) GetResults() []*PathResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type AllPairsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid       *GraphID          `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Algorithm AllPairsAlgorithm `protobuf:"varint,2,opt,name=algorithm,proto3,enum=graphservice.AllPairsAlgorithm" json:"algorithm,omitempty"`
+}
+
+func (x *AllPairsRequest) Reset() {
+	*x = AllPairsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AllPairsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllPairsRequest) ProtoMessage() {}
+
+func (x *AllPairsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllPairsRequest.ProtoReflect.Descriptor instead.
+func (*AllPairsRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AllPairsRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *AllPairsRequest) GetAlgorithm() AllPairsAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return AllPairsAlgorithm_ALL_PAIRS_AUTO
+}
+
+// The row of the distance matrix for one source vertex. Columns are the
+// vertices of the graph in ascending order of ID
+type DistanceRow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source int32 `protobuf:"varint,1,opt,name=source,proto3" json:"source,omitempty"`
+	// The cost of the shortest path from the source to each vertex,
+	// or 0 where reachable is false
+	Distances []int64 `protobuf:"varint,2,rep,packed,name=distances,proto3" json:"distances,omitempty"`
+	Reachable []bool  `protobuf:"varint,3,rep,packed,name=reachable,proto3" json:"reachable,omitempty"`
+	// The vertex IDs of the columns, only set in the first row
+	Vertices []int32 `protobuf:"varint,4,rep,packed,name=vertices,proto3" json:"vertices,omitempty"`
+}
+
+func (x *DistanceRow) Reset() {
+	*x = DistanceRow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DistanceRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DistanceRow) ProtoMessage() {}
+
+func (x *DistanceRow) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DistanceRow.ProtoReflect.Descriptor instead.
+func (*DistanceRow) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DistanceRow) GetSource() int32 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+func (x *DistanceRow) GetDistances() []int64 {
+	if x != nil {
+		return x.Distances
+	}
+	return nil
+}
+
+func (x *DistanceRow) GetReachable() []bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return nil
+}
+
+func (x *DistanceRow) GetVertices() []int32 {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+type TreeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid    *GraphID `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Source int32    `protobuf:"varint,2,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *TreeRequest) Reset() {
+	*x = TreeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TreeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeRequest) ProtoMessage() {}
+
+func (x *TreeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeRequest.ProtoReflect.Descriptor instead.
+func (*TreeRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TreeRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *TreeRequest) GetSource() int32 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+// A vertex reached by a PathTree
+type TreeVertex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The cost of the shortest path from the source
+	Distance int64 `protobuf:"varint,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	// The vertex before this one on its shortest path.
+	// The source is its own predecessor
+	Predecessor int32 `protobuf:"varint,3,opt,name=predecessor,proto3" json:"predecessor,omitempty"`
+}
+
+func (x *TreeVertex) Reset() {
+	*x = TreeVertex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TreeVertex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TreeVertex) ProtoMessage() {}
+
+func (x *TreeVertex) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TreeVertex.ProtoReflect.Descriptor instead.
+func (*TreeVertex) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TreeVertex) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TreeVertex) GetDistance() int64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *TreeVertex) GetPredecessor() int32 {
+	if x != nil {
+		return x.Predecessor
+	}
+	return 0
+}
+
+// The shortest paths from a source to every vertex it reaches. Vertices
+// are listed in order of distance and then of ID; unreachable vertices
+// are left out
+type PathTree struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source   int32         `protobuf:"varint,1,opt,name=source,proto3" json:"source,omitempty"`
+	Vertices []*TreeVertex `protobuf:"bytes,2,rep,name=vertices,proto3" json:"vertices,omitempty"`
+}
+
+func (x *PathTree) Reset() {
+	*x = PathTree{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathTree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathTree) ProtoMessage() {}
+
+func (x *PathTree) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathTree.ProtoReflect.Descriptor instead.
+func (*PathTree) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PathTree) GetSource() int32 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+func (x *PathTree) GetVertices() []*TreeVertex {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+type DeleteReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result string `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *DeleteReply) Reset() {
+	*x = DeleteReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteReply) ProtoMessage() {}
+
+func (x *DeleteReply) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteReply.ProtoReflect.Descriptor instead.
+func (*DeleteReply) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteReply) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+// A change to the vertices of a stored graph. Either every vertex
+// is added (or removed), or the graph is left unchanged
+type VerticesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid      *GraphID `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Vertices []int32  `protobuf:"varint,2,rep,packed,name=vertices,proto3" json:"vertices,omitempty"`
+	// The positions of added vertices, as in Graph
+	Positions map[int32]*Point `protobuf:"bytes,3,rep,name=positions,proto3" json:"positions,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *VerticesRequest) Reset() {
+	*x = VerticesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerticesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerticesRequest) ProtoMessage() {}
+
+func (x *VerticesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerticesRequest.ProtoReflect.Descriptor instead.
+func (*VerticesRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VerticesRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *VerticesRequest) GetVertices() []int32 {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+func (x *VerticesRequest) GetPositions() map[int32]*Point {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+// A change to the edges of a stored graph. Either every edge is
+// added (or removed), or the graph is left unchanged. The weights
+// of removed edges are ignored
+type EdgesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid   *GraphID `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Edges []*Edge  `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+}
+
+func (x *EdgesRequest) Reset() {
+	*x = EdgesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EdgesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EdgesRequest) ProtoMessage() {}
+
+func (x *EdgesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EdgesRequest.ProtoReflect.Descriptor instead.
+func (*EdgesRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EdgesRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *EdgesRequest) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+// The size of a graph after a mutation
+type MutationReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VertexCount int32 `protobuf:"varint,1,opt,name=vertex_count,json=vertexCount,proto3" json:"vertex_count,omitempty"`
+	EdgeCount   int32 `protobuf:"varint,2,opt,name=edge_count,json=edgeCount,proto3" json:"edge_count,omitempty"`
+}
+
+func (x *MutationReply) Reset() {
+	*x = MutationReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MutationReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MutationReply) ProtoMessage() {}
+
+func (x *MutationReply) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MutationReply.ProtoReflect.Descriptor instead.
+func (*MutationReply) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MutationReply) GetVertexCount() int32 {
+	if x != nil {
+		return x.VertexCount
+	}
+	return 0
+}
+
+func (x *MutationReply) GetEdgeCount() int32 {
+	if x != nil {
+		return x.EdgeCount
+	}
+	return 0
+}
+
+type ListGraphsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The maximum number of graphs to return. The server picks
+	// a default when it is 0, and caps larger values
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of the previous reply, or empty for
+	// the first page
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListGraphsRequest) Reset() {
+	*x = ListGraphsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGraphsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGraphsRequest) ProtoMessage() {}
+
+func (x *ListGraphsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGraphsRequest.ProtoReflect.Descriptor instead.
+func (*ListGraphsRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListGraphsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListGraphsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GraphInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid         *GraphID               `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	VertexCount int32                  `protobuf:"varint,2,opt,name=vertex_count,json=vertexCount,proto3" json:"vertex_count,omitempty"`
+	EdgeCount   int32                  `protobuf:"varint,3,opt,name=edge_count,json=edgeCount,proto3" json:"edge_count,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *GraphInfo) Reset() {
+	*x = GraphInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphInfo) ProtoMessage() {}
+
+func (x *GraphInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphInfo.ProtoReflect.Descriptor instead.
+func (*GraphInfo) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GraphInfo) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *GraphInfo) GetVertexCount() int32 {
+	if x != nil {
+		return x.VertexCount
+	}
+	return 0
+}
+
+func (x *GraphInfo) GetEdgeCount() int32 {
+	if x != nil {
+		return x.EdgeCount
+	}
+	return 0
+}
+
+func (x *GraphInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListGraphsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Graphs []*GraphInfo `protobuf:"bytes,1,rep,name=graphs,proto3" json:"graphs,omitempty"`
+	// Token for the next page, empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListGraphsReply) Reset() {
+	*x = ListGraphsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListGraphsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGraphsReply) ProtoMessage() {}
+
+func (x *ListGraphsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGraphsReply.ProtoReflect.Descriptor instead.
+func (*ListGraphsReply) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListGraphsReply) GetGraphs() []*GraphInfo {
+	if x != nil {
+		return x.Graphs
+	}
+	return nil
+}
+
+func (x *ListGraphsReply) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CacheStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CacheStatsRequest) Reset() {
+	*x = CacheStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CacheStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheStatsRequest) ProtoMessage() {}
+
+func (x *CacheStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheStatsRequest.ProtoReflect.Descriptor instead.
+func (*CacheStatsRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{28}
+}
+
+// The counters of the shortest path cache, which holds the shortest
+// paths from recently queried sources
+type CacheStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Queries answered from the cache, and queries that were not
+	Hits   uint64 `protobuf:"varint,1,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses uint64 `protobuf:"varint,2,opt,name=misses,proto3" json:"misses,omitempty"`
+	// Sources dropped to make room for others
+	Evictions uint64 `protobuf:"varint,3,opt,name=evictions,proto3" json:"evictions,omitempty"`
+	// The number of cached sources, and the most that fit
+	Size     int32 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Capacity int32 `protobuf:"varint,5,opt,name=capacity,proto3" json:"capacity,omitempty"`
+}
+
+func (x *CacheStats) Reset() {
+	*x = CacheStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheStats) ProtoMessage() {}
+
+func (x *CacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheStats.ProtoReflect.Descriptor instead.
+func (*CacheStats) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CacheStats) GetHits() uint64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *CacheStats) GetMisses() uint64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *CacheStats) GetEvictions() uint64 {
+	if x != nil {
+		return x.Evictions
+	}
+	return 0
+}
+
+func (x *CacheStats) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CacheStats) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+// Start preparing a graph, or follow the preparation already started.
+// The preparation goes on if the stream is canceled, and is dropped
+// when the graph changes
+type PrepareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid  *GraphID    `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Mode PrepareMode `protobuf:"varint,2,opt,name=mode,proto3,enum=graphservice.PrepareMode" json:"mode,omitempty"`
+	// Only used by LANDMARKS: the number of landmarks, 16 if 0,
+	// and how they are picked
+	Landmarks int32             `protobuf:"varint,3,opt,name=landmarks,proto3" json:"landmarks,omitempty"`
+	Selection LandmarkSelection `protobuf:"varint,4,opt,name=selection,proto3,enum=graphservice.LandmarkSelection" json:"selection,omitempty"`
+}
+
+func (x *PrepareRequest) Reset() {
+	*x = PrepareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrepareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareRequest) ProtoMessage() {}
+
+func (x *PrepareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareRequest.ProtoReflect.Descriptor instead.
+func (*PrepareRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PrepareRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *PrepareRequest) GetMode() PrepareMode {
+	if x != nil {
+		return x.Mode
+	}
+	return PrepareMode_PREPARE_UNSPECIFIED
+}
+
+func (x *PrepareRequest) GetLandmarks() int32 {
+	if x != nil {
+		return x.Landmarks
+	}
+	return 0
+}
+
+func (x *PrepareRequest) GetSelection() LandmarkSelection {
+	if x != nil {
+		return x.Selection
+	}
+	return LandmarkSelection_FARTHEST
+}
+
+type PrepareProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode PrepareMode `protobuf:"varint,1,opt,name=mode,proto3,enum=graphservice.PrepareMode" json:"mode,omitempty"`
+	// How much of the work is done, out of total steps. For CH,
+	// a step is ranking a vertex, and for LANDMARKS, finding the
+	// distances of a landmark
+	Done  int32 `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
+	Total int32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// Set on the last message, once queries use the preparation
+	Ready bool `protobuf:"varint,4,opt,name=ready,proto3" json:"ready,omitempty"`
+}
+
+func (x *PrepareProgress) Reset() {
+	*x = PrepareProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrepareProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrepareProgress) ProtoMessage() {}
+
+func (x *PrepareProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrepareProgress.ProtoReflect.Descriptor instead.
+func (*PrepareProgress) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PrepareProgress) GetMode() PrepareMode {
+	if x != nil {
+		return x.Mode
+	}
+	return PrepareMode_PREPARE_UNSPECIFIED
+}
+
+func (x *PrepareProgress) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *PrepareProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PrepareProgress) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+// The vertices of one connected component, in ascending order
+type Component struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vertices []int32 `protobuf:"varint,1,rep,packed,name=vertices,proto3" json:"vertices,omitempty"`
+}
+
+func (x *Component) Reset() {
+	*x = Component{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Component) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Component) ProtoMessage() {}
+
+func (x *Component) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Component.ProtoReflect.Descriptor instead.
+func (*Component) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Component) GetVertices() []int32 {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+// The connected components of a graph, in order of their lowest
+// vertex. Those of a directed graph ignore the direction of the
+// edges: they are its weakly connected components
+type ComponentList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Components []*Component `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+}
+
+func (x *ComponentList) Reset() {
+	*x = ComponentList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ComponentList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentList) ProtoMessage() {}
+
+func (x *ComponentList) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentList.ProtoReflect.Descriptor instead.
+func (*ComponentList) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ComponentList) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type ReachRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid *GraphID `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	S   int32    `protobuf:"varint,2,opt,name=s,proto3" json:"s,omitempty"`
+	T   int32    `protobuf:"varint,3,opt,name=t,proto3" json:"t,omitempty"`
+}
+
+func (x *ReachRequest) Reset() {
+	*x = ReachRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReachRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReachRequest) ProtoMessage() {}
+
+func (x *ReachRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReachRequest.ProtoReflect.Descriptor instead.
+func (*ReachRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReachRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *ReachRequest) GetS() int32 {
+	if x != nil {
+		return x.S
+	}
+	return 0
+}
+
+func (x *ReachRequest) GetT() int32 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+type Reachability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reachable bool `protobuf:"varint,1,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	// Whether s and t are in the same connected component
+	Connected bool `protobuf:"varint,2,opt,name=connected,proto3" json:"connected,omitempty"`
+}
+
+func (x *Reachability) Reset() {
+	*x = Reachability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reachability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reachability) ProtoMessage() {}
+
+func (x *Reachability) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reachability.ProtoReflect.Descriptor instead.
+func (*Reachability) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Reachability) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+func (x *Reachability) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+type SCCRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid *GraphID `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	// Also store the condensation as a new graph
+	StoreCondensation bool `protobuf:"varint,2,opt,name=store_condensation,json=storeCondensation,proto3" json:"store_condensation,omitempty"`
+}
+
+func (x *SCCRequest) Reset() {
+	*x = SCCRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SCCRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCCRequest) ProtoMessage() {}
+
+func (x *SCCRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCCRequest.ProtoReflect.Descriptor instead.
+func (*SCCRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SCCRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *SCCRequest) GetStoreCondensation() bool {
+	if x != nil {
+		return x.StoreCondensation
+	}
+	return false
+}
+
+type SCCReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The strongly connected components, in topological order of the
+	// condensation: every edge between two components goes from one
+	// to a later one. Those of more than one vertex hold the cycles
+	Components []*Component `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+	// The condensation, a directed acyclic graph with a vertex for
+	// each component, whose ID is its index in components. It has an
+	// edge from one component to another where the graph has edges
+	// between them, weighing as much as the lightest of those
+	Condensation *Graph `protobuf:"bytes,2,opt,name=condensation,proto3" json:"condensation,omitempty"`
+	// The ID of the stored condensation, if requested
+	CondensationId *GraphID `protobuf:"bytes,3,opt,name=condensation_id,json=condensationId,proto3" json:"condensation_id,omitempty"`
+}
+
+func (x *SCCReply) Reset() {
+	*x = SCCReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SCCReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SCCReply) ProtoMessage() {}
+
+func (x *SCCReply) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SCCReply.ProtoReflect.Descriptor instead.
+func (*SCCReply) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SCCReply) GetComponents() []*Component {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *SCCReply) GetCondensation() *Graph {
+	if x != nil {
+		return x.Condensation
+	}
+	return nil
+}
+
+func (x *SCCReply) GetCondensationId() *GraphID {
+	if x != nil {
+		return x.CondensationId
+	}
+	return nil
+}
+
+type TopoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid *GraphID `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	// Group the vertices into layers instead of ordering them
+	Layers bool `protobuf:"varint,2,opt,name=layers,proto3" json:"layers,omitempty"`
+}
+
+func (x *TopoRequest) Reset() {
+	*x = TopoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopoRequest) ProtoMessage() {}
+
+func (x *TopoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopoRequest.ProtoReflect.Descriptor instead.
+func (*TopoRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *TopoRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *TopoRequest) GetLayers() bool {
+	if x != nil {
+		return x.Layers
+	}
+	return false
+}
+
+// A group of vertices that only depend on vertices of earlier
+// layers, in ascending order
+type Layer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vertices []int32 `protobuf:"varint,1,rep,packed,name=vertices,proto3" json:"vertices,omitempty"`
+}
+
+func (x *Layer) Reset() {
+	*x = Layer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Layer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Layer) ProtoMessage() {}
+
+func (x *Layer) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Layer.ProtoReflect.Descriptor instead.
+func (*Layer) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Layer) GetVertices() []int32 {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+// Either order or layers is set, as requested. The vertices are in
+// topological order: every edge goes from a vertex to a later one,
+// and the lowest vertex that may come next comes first
+type TopoOrder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Order  []int32  `protobuf:"varint,1,rep,packed,name=order,proto3" json:"order,omitempty"`
+	Layers []*Layer `protobuf:"bytes,2,rep,name=layers,proto3" json:"layers,omitempty"`
+}
+
+func (x *TopoOrder) Reset() {
+	*x = TopoOrder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopoOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopoOrder) ProtoMessage() {}
+
+func (x *TopoOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopoOrder.ProtoReflect.Descriptor instead.
+func (*TopoOrder) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *TopoOrder) GetOrder() []int32 {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *TopoOrder) GetLayers() []*Layer {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+// Error detail of a FailedPrecondition error, for a graph that must
+// not have a cycle
+type Cycle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid *GraphID `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	// The vertices around the cycle, in the direction of its edges
+	Vertices []int32 `protobuf:"varint,2,rep,packed,name=vertices,proto3" json:"vertices,omitempty"`
+}
+
+func (x *Cycle) Reset() {
+	*x = Cycle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cycle) ProtoMessage() {}
+
+func (x *Cycle) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cycle.ProtoReflect.Descriptor instead.
+func (*Cycle) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Cycle) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *Cycle) GetVertices() []int32 {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+type MSTRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid       *GraphID     `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Algorithm MSTAlgorithm `protobuf:"varint,2,opt,name=algorithm,proto3,enum=graphservice.MSTAlgorithm" json:"algorithm,omitempty"`
+	// Also store the forest as a new graph, with every vertex
+	// of the graph and only the edges of the forest
+	Store bool `protobuf:"varint,3,opt,name=store,proto3" json:"store,omitempty"`
+}
+
+func (x *MSTRequest) Reset() {
+	*x = MSTRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MSTRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MSTRequest) ProtoMessage() {}
+
+func (x *MSTRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MSTRequest.ProtoReflect.Descriptor instead.
+func (*MSTRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MSTRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *MSTRequest) GetAlgorithm() MSTAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return MSTAlgorithm_KRUSKAL
+}
+
+func (x *MSTRequest) GetStore() bool {
+	if x != nil {
+		return x.Store
+	}
+	return false
+}
+
+// A minimum spanning forest: for each connected component of the
+// graph, a tree of edges joining all its vertices with the least total
+// weight. A connected graph has a single tree
+type SpanningForest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Each edge goes from its end of lower ID, in the order the
+	// algorithm added them
+	Edges []*Edge `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	// Total weight of the edges
+	Weight int64 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	// The ID of the stored forest, if requested
+	ForestId *GraphID `protobuf:"bytes,3,opt,name=forest_id,json=forestId,proto3" json:"forest_id,omitempty"`
+}
+
+func (x *SpanningForest) Reset() {
+	*x = SpanningForest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpanningForest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpanningForest) ProtoMessage() {}
+
+func (x *SpanningForest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpanningForest.ProtoReflect.Descriptor instead.
+func (*SpanningForest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SpanningForest) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *SpanningForest) GetWeight() int64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *SpanningForest) GetForestId() *GraphID {
+	if x != nil {
+		return x.ForestId
+	}
+	return nil
+}
+
+type FlowRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid       *GraphID      `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Source    int32         `protobuf:"varint,2,opt,name=source,proto3" json:"source,omitempty"`
+	Sink      int32         `protobuf:"varint,3,opt,name=sink,proto3" json:"sink,omitempty"`
+	Algorithm FlowAlgorithm `protobuf:"varint,4,opt,name=algorithm,proto3,enum=graphservice.FlowAlgorithm" json:"algorithm,omitempty"`
+}
+
+func (x *FlowRequest) Reset() {
+	*x = FlowRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowRequest) ProtoMessage() {}
+
+func (x *FlowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowRequest.ProtoReflect.Descriptor instead.
+func (*FlowRequest) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *FlowRequest) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *FlowRequest) GetSource() int32 {
+	if x != nil {
+		return x.Source
+	}
+	return 0
+}
+
+func (x *FlowRequest) GetSink() int32 {
+	if x != nil {
+		return x.Sink
+	}
+	return 0
+}
+
+func (x *FlowRequest) GetAlgorithm() FlowAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return FlowAlgorithm_DINIC
+}
+
+// The flow along an edge, from v1 to v2
+type EdgeFlow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	V1   *Vertex `protobuf:"bytes,1,opt,name=v1,proto3" json:"v1,omitempty"`
+	V2   *Vertex `protobuf:"bytes,2,opt,name=v2,proto3" json:"v2,omitempty"`
+	Flow int64   `protobuf:"varint,3,opt,name=flow,proto3" json:"flow,omitempty"`
+}
+
+func (x *EdgeFlow) Reset() {
+	*x = EdgeFlow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EdgeFlow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EdgeFlow) ProtoMessage() {}
+
+func (x *EdgeFlow) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EdgeFlow.ProtoReflect.Descriptor instead.
+func (*EdgeFlow) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *EdgeFlow) GetV1() *Vertex {
+	if x != nil {
+		return x.V1
+	}
+	return nil
+}
+
+func (x *EdgeFlow) GetV2() *Vertex {
+	if x != nil {
+		return x.V2
+	}
+	return nil
+}
+
+func (x *EdgeFlow) GetFlow() int64 {
+	if x != nil {
+		return x.Flow
+	}
+	return 0
+}
+
+// A maximum flow from the source to the sink, and a minimum cut
+// between them
+type FlowReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The total flow, which is also the total capacity of
+	// the edges from the source side to the sink side
+	Value int64 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	// The edges carrying flow. The flow of an undirected edge
+	// goes the way given here
+	Edges []*EdgeFlow `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	// The vertices on each side of the cut, in ascending order. The
+	// source side holds those to which the source could still send
+	// more flow, and every edge leaving it is saturated
+	SourceSide []int32 `protobuf:"varint,3,rep,packed,name=source_side,json=sourceSide,proto3" json:"source_side,omitempty"`
+	SinkSide   []int32 `protobuf:"varint,4,rep,packed,name=sink_side,json=sinkSide,proto3" json:"sink_side,omitempty"`
+}
+
+func (x *FlowReply) Reset() {
+	*x = FlowReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_graph_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlowReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowReply) ProtoMessage() {}
+
+func (x *FlowReply) ProtoReflect() protoreflect.Message {
+	mi := &file_graph_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowReply.ProtoReflect.Descriptor instead.
+func (*FlowReply) Descriptor() ([]byte, []int) {
+	return file_graph_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *FlowReply) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *FlowReply) GetEdges() []*EdgeFlow {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *FlowReply) GetSourceSide() []int32 {
+	if x != nil {
+		return x.SourceSide
+	}
+	return nil
+}
+
+func (x *FlowReply) GetSinkSide() []int32 {
+	if x != nil {
+		return x.SinkSide
+	}
+	return nil
+}
+
+var File_graph_proto protoreflect.FileDescriptor
+
+var file_graph_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x19, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x18, 0x0a, 0x06, 0x56, 0x65, 0x72, 0x74, 0x65,
+	0x78, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x19, 0x0a, 0x07, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x86, 0x01, 0x0a,
+	0x04, 0x45, 0x64, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x02, 0x76, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x52, 0x02, 0x76, 0x31, 0x12, 0x24, 0x0a, 0x02, 0x76,
+	0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x52, 0x02, 0x76,
+	0x32, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x22, 0x29, 0x0a, 0x09, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
+	0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73,
+	0x22, 0x23, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x01, 0x79, 0x22, 0x98, 0x03, 0x0a, 0x05, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12,
+	0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x05, 0x65,
+	0x64, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x2e,
+	0x45, 0x64, 0x67, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65,
+	0x73, 0x12, 0x39, 0x0a, 0x0e, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x5f, 0x65, 0x64,
+	0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x0d, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x51, 0x0a, 0x0a, 0x45, 0x64,
+	0x67, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
+	0x72, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x51, 0x0a,
+	0x0e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0xc8, 0x01, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x01, 0x74, 0x12, 0x39, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x41, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d,
+	0x12, 0x39, 0x0a, 0x09, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x48, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63,
+	0x52, 0x09, 0x68, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69, 0x63, 0x22, 0x54, 0x0a, 0x0d, 0x4e,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x27, 0x0a, 0x03,
+	0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44,
+	0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65,
+	0x73, 0x22, 0x48, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x63, 0x6f, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x73, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x22, 0x62, 0x0a, 0x0d, 0x4b,
+	0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x03,
+	0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44,
+	0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x01, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01,
+	0x74, 0x12, 0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6b, 0x22,
+	0x34, 0x0a, 0x08, 0x50, 0x61, 0x74, 0x68, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x70,
+	0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x05,
+	0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0x28, 0x0a, 0x0a, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x50,
+	0x61, 0x69, 0x72, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01,
+	0x73, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x74, 0x22,
+	0x6b, 0x0a, 0x10, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x2e, 0x0a, 0x05,
+	0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65,
+	0x78, 0x50, 0x61, 0x69, 0x72, 0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x22, 0x69, 0x0a, 0x09,
+	0x50, 0x61, 0x74, 0x68, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0x71, 0x0a, 0x0a, 0x50, 0x61, 0x74, 0x68, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x2f, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x61,
+	0x74, 0x68, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x44, 0x0a, 0x0e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x32, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x61, 0x74,
+	0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x22, 0x79, 0x0a, 0x0f, 0x41, 0x6c, 0x6c, 0x50, 0x61, 0x69, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x3d, 0x0a, 0x09,
+	0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1f, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41,
+	0x6c, 0x6c, 0x50, 0x61, 0x69, 0x72, 0x73, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d,
+	0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x22, 0x7d, 0x0a, 0x0b, 0x44,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x22, 0x4e, 0x0a, 0x0b, 0x54, 0x72,
+	0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x67, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x03, 0x67,
+	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x5a, 0x0a, 0x0a, 0x54, 0x72,
+	0x65, 0x65, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x64, 0x65, 0x63, 0x65, 0x73,
+	0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x64, 0x65,
+	0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x22, 0x58, 0x0a, 0x08, 0x50, 0x61, 0x74, 0x68, 0x54, 0x72,
+	0x65, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x76, 0x65,
+	0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x54, 0x72, 0x65, 0x65,
+	0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x52, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73,
+	0x22, 0x25, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0xf5, 0x01, 0x0a, 0x0f, 0x56, 0x65, 0x72, 0x74,
+	0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x67,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52,
+	0x03, 0x67, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73,
+	0x12, 0x4a, 0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x51, 0x0a, 0x0e,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x61, 0x0a, 0x0c, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70,
+	0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67,
+	0x65, 0x73, 0x22, 0x51, 0x0a, 0x0d, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x76, 0x65, 0x72, 0x74, 0x65,
+	0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x64, 0x67, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x4f, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xb1, 0x01, 0x0a, 0x09, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x76, 0x65, 0x72, 0x74, 0x65, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x64, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x64, 0x67, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x6a, 0x0a, 0x0f, 0x4c, 0x69,
+	0x73, 0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2f, 0x0a,
+	0x06, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x13, 0x0a, 0x11, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x86, 0x01, 0x0a, 0x0a,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x68, 0x69, 0x74, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x6d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x76, 0x69, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x65, 0x76, 0x69, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x22, 0xc5, 0x01, 0x0a, 0x0e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64,
+	0x12, 0x2d, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72,
+	0x65, 0x70, 0x61, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x6e, 0x64, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x6c, 0x61, 0x6e, 0x64, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x3d, 0x0a,
+	0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1f, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x4c, 0x61, 0x6e, 0x64, 0x6d, 0x61, 0x72, 0x6b, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x80, 0x01, 0x0a,
+	0x0f, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x2d, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
+	0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72,
+	0x65, 0x70, 0x61, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64,
+	0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61,
+	0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x22,
+	0x27, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08,
+	0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x22, 0x48, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x0a, 0x63, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x73, 0x22, 0x53, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x74, 0x22, 0x4a, 0x0a, 0x0c, 0x52, 0x65, 0x61, 0x63, 0x68,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68,
+	0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63,
+	0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x22, 0x64, 0x0a, 0x0a, 0x53, 0x43, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x65, 0x6e, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e,
+	0x64, 0x65, 0x6e, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xbc, 0x01, 0x0a, 0x08, 0x53, 0x43,
+	0x43, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x37, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x64, 0x65, 0x6e, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x64,
+	0x65, 0x6e, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x64,
+	0x65, 0x6e, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x64, 0x65, 0x6e,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x4e, 0x0a, 0x0b, 0x54, 0x6f, 0x70, 0x6f,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x22, 0x23, 0x0a, 0x05, 0x4c, 0x61, 0x79, 0x65,
+	0x72, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x22, 0x4e, 0x0a,
+	0x09, 0x54, 0x6f, 0x70, 0x6f, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x12, 0x2b, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x4c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x22, 0x4c, 0x0a,
+	0x05, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x27, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x08, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x0a,
+	0x4d, 0x53, 0x54, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x03, 0x67, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52, 0x03,
+	0x67, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4d, 0x53, 0x54, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x0e, 0x53, 0x70, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x46, 0x6f, 0x72, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x32, 0x0a, 0x09, 0x66, 0x6f, 0x72, 0x65,
+	0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x49, 0x44, 0x52, 0x08, 0x66, 0x6f, 0x72, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x9d, 0x01, 0x0a,
+	0x0b, 0x46, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x03,
+	0x67, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44,
+	0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x6e,
+	0x6b, 0x12, 0x39, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68,
+	0x6d, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x22, 0x6a, 0x0a, 0x08,
+	0x45, 0x64, 0x67, 0x65, 0x46, 0x6c, 0x6f, 0x77, 0x12, 0x24, 0x0a, 0x02, 0x76, 0x31, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78, 0x52, 0x02, 0x76, 0x31, 0x12, 0x24,
+	0x0a, 0x02, 0x76, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x65, 0x78,
+	0x52, 0x02, 0x76, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x66, 0x6c, 0x6f, 0x77, 0x22, 0x8d, 0x01, 0x0a, 0x09, 0x46, 0x6c, 0x6f,
+	0x77, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2c, 0x0a, 0x05,
+	0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x46,
+	0x6c, 0x6f, 0x77, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x73, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52,
+	0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x69, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73,
+	0x69, 0x6e, 0x6b, 0x5f, 0x73, 0x69, 0x64, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08,
+	0x73, 0x69, 0x6e, 0x6b, 0x53, 0x69, 0x64, 0x65, 0x2a, 0x66, 0x0a, 0x0d, 0x50, 0x61, 0x74, 0x68,
+	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x41, 0x54,
+	0x48, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x49, 0x4a, 0x4b,
+	0x53, 0x54, 0x52, 0x41, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x5f, 0x53, 0x54, 0x41, 0x52,
+	0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x49, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x49, 0x4f,
+	0x4e, 0x41, 0x4c, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x42, 0x45, 0x4c, 0x4c, 0x4d, 0x41, 0x4e,
+	0x5f, 0x46, 0x4f, 0x52, 0x44, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4c, 0x54, 0x10, 0x05,
+	0x2a, 0x2d, 0x0a, 0x0d, 0x50, 0x61, 0x74, 0x68, 0x48, 0x65, 0x75, 0x72, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x55, 0x43, 0x4c, 0x49, 0x44, 0x45, 0x41, 0x4e, 0x10, 0x00,
+	0x12, 0x0d, 0x0a, 0x09, 0x48, 0x41, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4e, 0x45, 0x10, 0x01, 0x2a,
+	0x48, 0x0a, 0x11, 0x41, 0x6c, 0x6c, 0x50, 0x61, 0x69, 0x72, 0x73, 0x41, 0x6c, 0x67, 0x6f, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x4c, 0x5f, 0x50, 0x41, 0x49, 0x52,
+	0x53, 0x5f, 0x41, 0x55, 0x54, 0x4f, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x4c, 0x4f, 0x59,
+	0x44, 0x5f, 0x57, 0x41, 0x52, 0x53, 0x48, 0x41, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07,
+	0x4a, 0x4f, 0x48, 0x4e, 0x53, 0x4f, 0x4e, 0x10, 0x02, 0x2a, 0x3d, 0x0a, 0x0b, 0x50, 0x72, 0x65,
+	0x70, 0x61, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x52, 0x45, 0x50,
+	0x41, 0x52, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x06, 0x0a, 0x02, 0x43, 0x48, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4c, 0x41, 0x4e,
+	0x44, 0x4d, 0x41, 0x52, 0x4b, 0x53, 0x10, 0x02, 0x2a, 0x2d, 0x0a, 0x11, 0x4c, 0x61, 0x6e, 0x64,
+	0x6d, 0x61, 0x72, 0x6b, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a,
+	0x08, 0x46, 0x41, 0x52, 0x54, 0x48, 0x45, 0x53, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x52,
+	0x41, 0x4e, 0x44, 0x4f, 0x4d, 0x10, 0x01, 0x2a, 0x25, 0x0a, 0x0c, 0x4d, 0x53, 0x54, 0x41, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0b, 0x0a, 0x07, 0x4b, 0x52, 0x55, 0x53, 0x4b,
+	0x41, 0x4c, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x52, 0x49, 0x4d, 0x10, 0x01, 0x2a, 0x2c,
+	0x0a, 0x0d, 0x46, 0x6c, 0x6f, 0x77, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12,
+	0x09, 0x0a, 0x05, 0x44, 0x49, 0x4e, 0x49, 0x43, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x55,
+	0x53, 0x48, 0x5f, 0x52, 0x45, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x10, 0x01, 0x32, 0xa6, 0x0c, 0x0a,
+	0x0c, 0x47, 0x72, 0x61, 0x70, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a,
+	0x09, 0x50, 0x6f, 0x73, 0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x13, 0x2e, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x1a,
+	0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0c, 0x53, 0x68, 0x6f, 0x72,
+	0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x19, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0e, 0x4b, 0x53, 0x68,
+	0x6f, 0x72, 0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x1b, 0x2e, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4b, 0x50, 0x61, 0x74, 0x68,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x4c, 0x69, 0x73, 0x74,
+	0x22, 0x00, 0x12, 0x53, 0x0a, 0x11, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x68, 0x6f, 0x72, 0x74,
+	0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x74, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x74, 0x68,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x15, 0x41, 0x6c, 0x6c, 0x50, 0x61,
+	0x69, 0x72, 0x73, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73,
+	0x12, 0x1d, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x41, 0x6c, 0x6c, 0x50, 0x61, 0x69, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44,
+	0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x6f, 0x77, 0x22, 0x00, 0x30, 0x01, 0x12, 0x47,
+	0x0a, 0x10, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x65, 0x73, 0x74, 0x50, 0x61, 0x74, 0x68, 0x54, 0x72,
+	0x65, 0x65, 0x12, 0x19, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x61, 0x74,
+	0x68, 0x54, 0x72, 0x65, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x19, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0b, 0x41, 0x64,
+	0x64, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x08, 0x41, 0x64, 0x64, 0x45, 0x64,
+	0x67, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4d,
+	0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x48,
+	0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x1a, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x64, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x12, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a, 0x13, 0x2e, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x73,
+	0x12, 0x1f, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x00,
+	0x12, 0x4f, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x12, 0x1c, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72,
+	0x65, 0x70, 0x61, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x4b, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x1a,
+	0x1b, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x47,
+	0x0a, 0x0b, 0x49, 0x73, 0x52, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1a, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x61,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x1b, 0x53, 0x74, 0x72, 0x6f, 0x6e,
+	0x67, 0x6c, 0x79, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x43, 0x43, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x53, 0x43, 0x43, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0f, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x53, 0x6f, 0x72, 0x74, 0x12, 0x19, 0x2e,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x54, 0x6f, 0x70,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x13, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x53, 0x70,
+	0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x54, 0x72, 0x65, 0x65, 0x12, 0x18, 0x2e, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4d, 0x53, 0x54, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x65,
+	0x73, 0x74, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x07, 0x4d, 0x61, 0x78, 0x46, 0x6c, 0x6f, 0x77, 0x12,
+	0x19, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x46,
+	0x6c, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x46, 0x6c, 0x6f, 0x77, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x79, 0x63, 0x32, 0x34, 0x35, 0x34, 0x2f, 0x47, 0x72, 0x61, 0x70, 0x68,
+	0x2d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_graph_proto_rawDescOnce sync.Once
+	file_graph_proto_rawDescData = file_graph_proto_rawDesc
+)
+
+func file_graph_proto_rawDescGZIP() []byte {
+	file_graph_proto_rawDescOnce.Do(func() {
+		file_graph_proto_rawDescData = protoimpl.X.CompressGZIP(file_graph_proto_rawDescData)
+	})
+	return file_graph_proto_rawDescData
+}
+
+var file_graph_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_graph_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
+var file_graph_proto_goTypes = []interface{}{
+	(PathAlgorithm)(0),            // 0: graphservice.PathAlgorithm
+	(PathHeuristic)(0),            // 1: graphservice.PathHeuristic
+	(AllPairsAlgorithm)(0),        // 2: graphservice.AllPairsAlgorithm
+	(PrepareMode)(0),              // 3: graphservice.PrepareMode
+	(LandmarkSelection)(0),        // 4: graphservice.LandmarkSelection
+	(MSTAlgorithm)(0),             // 5: graphservice.MSTAlgorithm
+	(FlowAlgorithm)(0),            // 6: graphservice.FlowAlgorithm
+	(*Vertex)(nil),                // 7: graphservice.Vertex
+	(*GraphID)(nil),               // 8: graphservice.GraphID
+	(*Edge)(nil),                  // 9: graphservice.Edge
+	(*Neighbors)(nil),             // 10: graphservice.Neighbors
+	(*Point)(nil),                 // 11: graphservice.Point
+	(*Graph)(nil),                 // 12: graphservice.Graph
+	(*PathRequest)(nil),           // 13: graphservice.PathRequest
+	(*NegativeCycle)(nil),         // 14: graphservice.NegativeCycle
+	(*Path)(nil),                  // 15: graphservice.Path
+	(*KPathsRequest)(nil),         // 16: graphservice.KPathsRequest
+	(*PathList)(nil),              // 17: graphservice.PathList
+	(*VertexPair)(nil),            // 18: graphservice.VertexPair
+	(*BatchPathRequest)(nil),      // 19: graphservice.BatchPathRequest
+	(*PathError)(nil),             // 20: graphservice.PathError
+	(*PathResult)(nil),            // 21: graphservice.PathResult
+	(*BatchPathReply)(nil),        // 22: graphservice.BatchPathReply
+	(*AllPairsRequest)(nil),       // 23: graphservice.AllPairsRequest
+	(*DistanceRow)(nil),           // 24: graphservice.DistanceRow
+	(*TreeRequest)(nil),           // 25: graphservice.TreeRequest
+	(*TreeVertex)(nil),            // 26: graphservice.TreeVertex
+	(*PathTree)(nil),              // 27: graphservice.PathTree
+	(*DeleteReply)(nil),           // 28: graphservice.DeleteReply
+	(*VerticesRequest)(nil),       // 29: graphservice.VerticesRequest
+	(*EdgesRequest)(nil),          // 30: graphservice.EdgesRequest
+	(*MutationReply)(nil),         // 31: graphservice.MutationReply
+	(*ListGraphsRequest)(nil),     // 32: graphservice.ListGraphsRequest
+	(*GraphInfo)(nil),             // 33: graphservice.GraphInfo
+	(*ListGraphsReply)(nil),       // 34: graphservice.ListGraphsReply
+	(*CacheStatsRequest)(nil),     // 35: graphservice.CacheStatsRequest
+	(*CacheStats)(nil),            // 36: graphservice.CacheStats
+	(*PrepareRequest)(nil),        // 37: graphservice.PrepareRequest
+	(*PrepareProgress)(nil),       // 38: graphservice.PrepareProgress
+	(*Component)(nil),             // 39: graphservice.Component
+	(*ComponentList)(nil),         // 40: graphservice.ComponentList
+	(*ReachRequest)(nil),          // 41: graphservice.ReachRequest
+	(*Reachability)(nil),          // 42: graphservice.Reachability
+	(*SCCRequest)(nil),            // 43: graphservice.SCCRequest
+	(*SCCReply)(nil),              // 44: graphservice.SCCReply
+	(*TopoRequest)(nil),           // 45: graphservice.TopoRequest
+	(*Layer)(nil),                 // 46: graphservice.Layer
+	(*TopoOrder)(nil),             // 47: graphservice.TopoOrder
+	(*Cycle)(nil),                 // 48: graphservice.Cycle
+	(*MSTRequest)(nil),            // 49: graphservice.MSTRequest
+	(*SpanningForest)(nil),        // 50: graphservice.SpanningForest
+	(*FlowRequest)(nil),           // 51: graphservice.FlowRequest
+	(*EdgeFlow)(nil),              // 52: graphservice.EdgeFlow
+	(*FlowReply)(nil),             // 53: graphservice.FlowReply
+	nil,                           // 54: graphservice.Graph.EdgesEntry
+	nil,                           // 55: graphservice.Graph.PositionsEntry
+	nil,                           // 56: graphservice.VerticesRequest.PositionsEntry
+	(*anypb.Any)(nil),             // 57: google.protobuf.Any
+	(*timestamppb.Timestamp)(nil), // 58: google.protobuf.Timestamp
+}
+var file_graph_proto_depIdxs = []int32{
+	7,  // 0: graphservice.Edge.v1:type_name -> graphservice.Vertex
+	7,  // 1: graphservice.Edge.v2:type_name -> graphservice.Vertex
+	54, // 2: graphservice.Graph.edges:type_name -> graphservice.Graph.EdgesEntry
+	9,  // 3: graphservice.Graph.weighted_edges:type_name -> graphservice.Edge
+	55, // 4: graphservice.Graph.positions:type_name -> graphservice.Graph.PositionsEntry
+	8,  // 5: graphservice.PathRequest.gid:type_name -> graphservice.GraphID
+	0,  // 6: graphservice.PathRequest.algorithm:type_name -> graphservice.PathAlgorithm
+	1,  // 7: graphservice.PathRequest.heuristic:type_name -> graphservice.PathHeuristic
+	8,  // 8: graphservice.NegativeCycle.gid:type_name -> graphservice.GraphID
+	8,  // 9: graphservice.KPathsRequest.gid:type_name -> graphservice.GraphID
+	15, // 10: graphservice.PathList.paths:type_name -> graphservice.Path
+	8,  // 11: graphservice.BatchPathRequest.gid:type_name -> graphservice.GraphID
+	18, // 12: graphservice.BatchPathRequest.pairs:type_name -> graphservice.VertexPair
+	57, // 13: graphservice.PathError.details:type_name -> google.protobuf.Any
+	15, // 14: graphservice.PathResult.path:type_name -> graphservice.Path
+	20, // 15: graphservice.PathResult.error:type_name -> graphservice.PathError
+	21, // 16: graphservice.BatchPathReply.results:type_name -> graphservice.PathResult
+	8,  // 17: graphservice.AllPairsRequest.gid:type_name -> graphservice.GraphID
+	2,  // 18: graphservice.AllPairsRequest.algorithm:type_name -> graphservice.AllPairsAlgorithm
+	8,  // 19: graphservice.TreeRequest.gid:type_name -> graphservice.GraphID
+	26, // 20: graphservice.PathTree.vertices:type_name -> graphservice.TreeVertex
+	8,  // 21: graphservice.VerticesRequest.gid:type_name -> graphservice.GraphID
+	56, // 22: graphservice.VerticesRequest.positions:type_name -> graphservice.VerticesRequest.PositionsEntry
+	8,  // 23: graphservice.EdgesRequest.gid:type_name -> graphservice.GraphID
+	9,  // 24: graphservice.EdgesRequest.edges:type_name -> graphservice.Edge
+	8,  // 25: graphservice.GraphInfo.gid:type_name -> graphservice.GraphID
+	58, // 26: graphservice.GraphInfo.created_at:type_name -> google.protobuf.Timestamp
+	33, // 27: graphservice.ListGraphsReply.graphs:type_name -> graphservice.GraphInfo
+	8,  // 28: graphservice.PrepareRequest.gid:type_name -> graphservice.GraphID
+	3,  // 29: graphservice.PrepareRequest.mode:type_name -> graphservice.PrepareMode
+	4,  // 30: graphservice.PrepareRequest.selection:type_name -> graphservice.LandmarkSelection
+	3,  // 31: graphservice.PrepareProgress.mode:type_name -> graphservice.PrepareMode
+	39, // 32: graphservice.ComponentList.components:type_name -> graphservice.Component
+	8,  // 33: graphservice.ReachRequest.gid:type_name -> graphservice.GraphID
+	8,  // 34: graphservice.SCCRequest.gid:type_name -> graphservice.GraphID
+	39, // 35: graphservice.SCCReply.components:type_name -> graphservice.Component
+	12, // 36: graphservice.SCCReply.condensation:type_name -> graphservice.Graph
+	8,  // 37: graphservice.SCCReply.condensation_id:type_name -> graphservice.GraphID
+	8,  // 38: graphservice.TopoRequest.gid:type_name -> graphservice.GraphID
+	46, // 39: graphservice.TopoOrder.layers:type_name -> graphservice.Layer
+	8,  // 40: graphservice.Cycle.gid:type_name -> graphservice.GraphID
+	8,  // 41: graphservice.MSTRequest.gid:type_name -> graphservice.GraphID
+	5,  // 42: graphservice.MSTRequest.algorithm:type_name -> graphservice.MSTAlgorithm
+	9,  // 43: graphservice.SpanningForest.edges:type_name -> graphservice.Edge
+	8,  // 44: graphservice.SpanningForest.forest_id:type_name -> graphservice.GraphID
+	8,  // 45: graphservice.FlowRequest.gid:type_name -> graphservice.GraphID
+	6,  // 46: graphservice.FlowRequest.algorithm:type_name -> graphservice.FlowAlgorithm
+	7,  // 47: graphservice.EdgeFlow.v1:type_name -> graphservice.Vertex
+	7,  // 48: graphservice.EdgeFlow.v2:type_name -> graphservice.Vertex
+	52, // 49: graphservice.FlowReply.edges:type_name -> graphservice.EdgeFlow
+	10, // 50: graphservice.Graph.EdgesEntry.value:type_name -> graphservice.Neighbors
+	11, // 51: graphservice.Graph.PositionsEntry.value:type_name -> graphservice.Point
+	11, // 52: graphservice.VerticesRequest.PositionsEntry.value:type_name -> graphservice.Point
+	12, // 53: graphservice.GraphService.PostGraph:input_type -> graphservice.Graph
+	13, // 54: graphservice.GraphService.ShortestPath:input_type -> graphservice.PathRequest
+	16, // 55: graphservice.GraphService.KShortestPaths:input_type -> graphservice.KPathsRequest
+	19, // 56: graphservice.GraphService.BatchShortestPath:input_type -> graphservice.BatchPathRequest
+	23, // 57: graphservice.GraphService.AllPairsShortestPaths:input_type -> graphservice.AllPairsRequest
+	25, // 58: graphservice.GraphService.ShortestPathTree:input_type -> graphservice.TreeRequest
+	8,  // 59: graphservice.GraphService.DeleteGraph:input_type -> graphservice.GraphID
+	29, // 60: graphservice.GraphService.AddVertices:input_type -> graphservice.VerticesRequest
+	29, // 61: graphservice.GraphService.RemoveVertices:input_type -> graphservice.VerticesRequest
+	30, // 62: graphservice.GraphService.AddEdges:input_type -> graphservice.EdgesRequest
+	30, // 63: graphservice.GraphService.RemoveEdges:input_type -> graphservice.EdgesRequest
+	8,  // 64: graphservice.GraphService.GetGraph:input_type -> graphservice.GraphID
+	32, // 65: graphservice.GraphService.ListGraphs:input_type -> graphservice.ListGraphsRequest
+	35, // 66: graphservice.GraphService.GetCacheStats:input_type -> graphservice.CacheStatsRequest
+	37, // 67: graphservice.GraphService.PrepareGraph:input_type -> graphservice.PrepareRequest
+	8,  // 68: graphservice.GraphService.ConnectedComponents:input_type -> graphservice.GraphID
+	41, // 69: graphservice.GraphService.IsReachable:input_type -> graphservice.ReachRequest
+	43, // 70: graphservice.GraphService.StronglyConnectedComponents:input_type -> graphservice.SCCRequest
+	45, // 71: graphservice.GraphService.TopologicalSort:input_type -> graphservice.TopoRequest
+	49, // 72: graphservice.GraphService.MinimumSpanningTree:input_type -> graphservice.MSTRequest
+	51, // 73: graphservice.GraphService.MaxFlow:input_type -> graphservice.FlowRequest
+	8,  // 74: graphservice.GraphService.PostGraph:output_type -> graphservice.GraphID
+	15, // 75: graphservice.GraphService.ShortestPath:output_type -> graphservice.Path
+	17, // 76: graphservice.GraphService.KShortestPaths:output_type -> graphservice.PathList
+	22, // 77: graphservice.GraphService.BatchShortestPath:output_type -> graphservice.BatchPathReply
+	24, // 78: graphservice.GraphService.AllPairsShortestPaths:output_type -> graphservice.DistanceRow
+	27, // 79: graphservice.GraphService.ShortestPathTree:output_type -> graphservice.PathTree
+	28, // 80: graphservice.GraphService.DeleteGraph:output_type -> graphservice.DeleteReply
+	31, // 81: graphservice.GraphService.AddVertices:output_type -> graphservice.MutationReply
+	31, // 82: graphservice.GraphService.RemoveVertices:output_type -> graphservice.MutationReply
+	31, // 83: graphservice.GraphService.AddEdges:output_type -> graphservice.MutationReply
+	31, // 84: graphservice.GraphService.RemoveEdges:output_type -> graphservice.MutationReply
+	12, // 85: graphservice.GraphService.GetGraph:output_type -> graphservice.Graph
+	34, // 86: graphservice.GraphService.ListGraphs:output_type -> graphservice.ListGraphsReply
+	36, // 87: graphservice.GraphService.GetCacheStats:output_type -> graphservice.CacheStats
+	38, // 88: graphservice.GraphService.PrepareGraph:output_type -> graphservice.PrepareProgress
+	40, // 89: graphservice.GraphService.ConnectedComponents:output_type -> graphservice.ComponentList
+	42, // 90: graphservice.GraphService.IsReachable:output_type -> graphservice.Reachability
+	44, // 91: graphservice.GraphService.StronglyConnectedComponents:output_type -> graphservice.SCCReply
+	47, // 92: graphservice.GraphService.TopologicalSort:output_type -> graphservice.TopoOrder
+	50, // 93: graphservice.GraphService.MinimumSpanningTree:output_type -> graphservice.SpanningForest
+	53, // 94: graphservice.GraphService.MaxFlow:output_type -> graphservice.FlowReply
+	74, // [74:95] is the sub-list for method output_type
+	53, // [53:74] is the sub-list for method input_type
+	53, // [53:53] is the sub-list for extension type_name
+	53, // [53:53] is the sub-list for extension extendee
+	0,  // [0:53] is the sub-list for field type_name
+}
+
+func init() { file_graph_proto_init() }
+func file_graph_proto_init() {
+	if File_graph_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_graph_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Vertex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Edge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Neighbors); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Point); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Graph); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NegativeCycle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Path); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KPathsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VertexPair); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchPathRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchPathReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AllPairsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DistanceRow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TreeVertex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PathTree); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerticesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -431,8 +4215,8 @@ func file_graph_proto_init() {
 				return nil
 			}
 		}
-		file_graph_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Neighbors); i {
+		file_graph_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -443,8 +4227,8 @@ func file_graph_proto_init() {
 				return nil
 			}
 		}
-		file_graph_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Graph); i {
+		file_graph_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MutationReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -455,8 +4239,8 @@ func file_graph_proto_init() {
 				return nil
 			}
 		}
-		file_graph_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PathRequest); i {
+		file_graph_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGraphsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -467,8 +4251,8 @@ func file_graph_proto_init() {
 				return nil
 			}
 		}
-		file_graph_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Path); i {
+		file_graph_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -479,8 +4263,236 @@ func file_graph_proto_init() {
 				return nil
 			}
 		}
-		file_graph_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteReply); i {
+		file_graph_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListGraphsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CacheStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CacheStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrepareProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Component); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ComponentList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReachRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Reachability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SCCRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SCCReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Layer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopoOrder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cycle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MSTRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpanningForest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlowRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EdgeFlow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_graph_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlowReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -492,18 +4504,23 @@ func file_graph_proto_init() {
 			}
 		}
 	}
+	file_graph_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*PathResult_Path)(nil),
+		(*PathResult_Error)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_graph_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      7,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_graph_proto_goTypes,
 		DependencyIndexes: file_graph_proto_depIdxs,
+		EnumInfos:         file_graph_proto_enumTypes,
 		MessageInfos:      file_graph_proto_msgTypes,
 	}.Build()
 	File_graph_proto = out.File
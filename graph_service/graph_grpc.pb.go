@@ -26,8 +26,50 @@ type GraphServiceClient interface {
 	PostGraph(ctx context.Context, in *Graph, opts ...grpc.CallOption) (*GraphID, error)
 	// Find the shortest path
 	ShortestPath(ctx context.Context, in *PathRequest, opts ...grpc.CallOption) (*Path, error)
+	// Find the k shortest paths between two vertices that visit no vertex twice
+	KShortestPaths(ctx context.Context, in *KPathsRequest, opts ...grpc.CallOption) (*PathList, error)
+	// Find the shortest paths between many pairs of vertices of a graph
+	BatchShortestPath(ctx context.Context, in *BatchPathRequest, opts ...grpc.CallOption) (*BatchPathReply, error)
+	// Find the cost of the shortest path between every pair of vertices,
+	// streamed one row of the distance matrix at a time
+	AllPairsShortestPaths(ctx context.Context, in *AllPairsRequest, opts ...grpc.CallOption) (GraphService_AllPairsShortestPathsClient, error)
+	// Find the shortest paths from one vertex to every vertex it reaches
+	ShortestPathTree(ctx context.Context, in *TreeRequest, opts ...grpc.CallOption) (*PathTree, error)
 	// Delete the graph
 	DeleteGraph(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*DeleteReply, error)
+	// Add vertices to a stored graph
+	AddVertices(ctx context.Context, in *VerticesRequest, opts ...grpc.CallOption) (*MutationReply, error)
+	// Remove vertices, and the edges touching them, from a stored graph
+	RemoveVertices(ctx context.Context, in *VerticesRequest, opts ...grpc.CallOption) (*MutationReply, error)
+	// Add edges to a stored graph, or change the weight of existing ones
+	AddEdges(ctx context.Context, in *EdgesRequest, opts ...grpc.CallOption) (*MutationReply, error)
+	// Remove edges from a stored graph
+	RemoveEdges(ctx context.Context, in *EdgesRequest, opts ...grpc.CallOption) (*MutationReply, error)
+	// Get a stored graph
+	GetGraph(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*Graph, error)
+	// List the stored graphs, in order of ID
+	ListGraphs(ctx context.Context, in *ListGraphsRequest, opts ...grpc.CallOption) (*ListGraphsReply, error)
+	// Get the counters of the shortest path cache
+	GetCacheStats(ctx context.Context, in *CacheStatsRequest, opts ...grpc.CallOption) (*CacheStats, error)
+	// Preprocess a graph in the background for faster queries, streaming
+	// the progress until it is done
+	PrepareGraph(ctx context.Context, in *PrepareRequest, opts ...grpc.CallOption) (GraphService_PrepareGraphClient, error)
+	// Find the connected components of a graph
+	ConnectedComponents(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*ComponentList, error)
+	// Tell whether there is a path from one vertex to another
+	IsReachable(ctx context.Context, in *ReachRequest, opts ...grpc.CallOption) (*Reachability, error)
+	// Find the strongly connected components of a graph, and the DAG
+	// they condense it to
+	StronglyConnectedComponents(ctx context.Context, in *SCCRequest, opts ...grpc.CallOption) (*SCCReply, error)
+	// Order the vertices of a directed acyclic graph so that every edge
+	// goes forward, or group them into layers
+	TopologicalSort(ctx context.Context, in *TopoRequest, opts ...grpc.CallOption) (*TopoOrder, error)
+	// Find a minimum spanning tree of each connected component of an
+	// undirected graph
+	MinimumSpanningTree(ctx context.Context, in *MSTRequest, opts ...grpc.CallOption) (*SpanningForest, error)
+	// Find a maximum flow between two vertices through the capacities
+	// of the edges, and a minimum cut between them
+	MaxFlow(ctx context.Context, in *FlowRequest, opts ...grpc.CallOption) (*FlowReply, error)
 }
 
 type graphServiceClient struct {
@@ -56,6 +98,65 @@ func (c *graphServiceClient) ShortestPath(ctx context.Context, in *PathRequest,
 	return out, nil
 }
 
+func (c *graphServiceClient) KShortestPaths(ctx context.Context, in *KPathsRequest, opts ...grpc.CallOption) (*PathList, error) {
+	out := new(PathList)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/KShortestPaths", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) BatchShortestPath(ctx context.Context, in *BatchPathRequest, opts ...grpc.CallOption) (*BatchPathReply, error) {
+	out := new(BatchPathReply)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/BatchShortestPath", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) AllPairsShortestPaths(ctx context.Context, in *AllPairsRequest, opts ...grpc.CallOption) (GraphService_AllPairsShortestPathsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GraphService_ServiceDesc.Streams[0], "/graphservice.GraphService/AllPairsShortestPaths", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &graphServiceAllPairsShortestPathsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GraphService_AllPairsShortestPathsClient interface {
+	Recv() (*DistanceRow, error)
+	grpc.ClientStream
+}
+
+type graphServiceAllPairsShortestPathsClient struct {
+	grpc.ClientStream
+}
+
+func (x *graphServiceAllPairsShortestPathsClient) Recv() (*DistanceRow, error) {
+	m := new(DistanceRow)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *graphServiceClient) ShortestPathTree(ctx context.Context, in *TreeRequest, opts ...grpc.CallOption) (*PathTree, error) {
+	out := new(PathTree)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/ShortestPathTree", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *graphServiceClient) DeleteGraph(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*DeleteReply, error) {
 	out := new(DeleteReply)
 	err := c.cc.Invoke(ctx, "/graphservice.GraphService/DeleteGraph", in, out, opts...)
@@ -65,6 +166,155 @@ func (c *graphServiceClient) DeleteGraph(ctx context.Context, in *GraphID, opts
 	return out, nil
 }
 
+func (c *graphServiceClient) AddVertices(ctx context.Context, in *VerticesRequest, opts ...grpc.CallOption) (*MutationReply, error) {
+	out := new(MutationReply)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/AddVertices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) RemoveVertices(ctx context.Context, in *VerticesRequest, opts ...grpc.CallOption) (*MutationReply, error) {
+	out := new(MutationReply)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/RemoveVertices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) AddEdges(ctx context.Context, in *EdgesRequest, opts ...grpc.CallOption) (*MutationReply, error) {
+	out := new(MutationReply)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/AddEdges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) RemoveEdges(ctx context.Context, in *EdgesRequest, opts ...grpc.CallOption) (*MutationReply, error) {
+	out := new(MutationReply)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/RemoveEdges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) GetGraph(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*Graph, error) {
+	out := new(Graph)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/GetGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) ListGraphs(ctx context.Context, in *ListGraphsRequest, opts ...grpc.CallOption) (*ListGraphsReply, error) {
+	out := new(ListGraphsReply)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/ListGraphs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) GetCacheStats(ctx context.Context, in *CacheStatsRequest, opts ...grpc.CallOption) (*CacheStats, error) {
+	out := new(CacheStats)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/GetCacheStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) PrepareGraph(ctx context.Context, in *PrepareRequest, opts ...grpc.CallOption) (GraphService_PrepareGraphClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GraphService_ServiceDesc.Streams[1], "/graphservice.GraphService/PrepareGraph", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &graphServicePrepareGraphClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GraphService_PrepareGraphClient interface {
+	Recv() (*PrepareProgress, error)
+	grpc.ClientStream
+}
+
+type graphServicePrepareGraphClient struct {
+	grpc.ClientStream
+}
+
+func (x *graphServicePrepareGraphClient) Recv() (*PrepareProgress, error) {
+	m := new(PrepareProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *graphServiceClient) ConnectedComponents(ctx context.Context, in *GraphID, opts ...grpc.CallOption) (*ComponentList, error) {
+	out := new(ComponentList)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/ConnectedComponents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) IsReachable(ctx context.Context, in *ReachRequest, opts ...grpc.CallOption) (*Reachability, error) {
+	out := new(Reachability)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/IsReachable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) StronglyConnectedComponents(ctx context.Context, in *SCCRequest, opts ...grpc.CallOption) (*SCCReply, error) {
+	out := new(SCCReply)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/StronglyConnectedComponents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) TopologicalSort(ctx context.Context, in *TopoRequest, opts ...grpc.CallOption) (*TopoOrder, error) {
+	out := new(TopoOrder)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/TopologicalSort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) MinimumSpanningTree(ctx context.Context, in *MSTRequest, opts ...grpc.CallOption) (*SpanningForest, error) {
+	out := new(SpanningForest)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/MinimumSpanningTree", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphServiceClient) MaxFlow(ctx context.Context, in *FlowRequest, opts ...grpc.CallOption) (*FlowReply, error) {
+	out := new(FlowReply)
+	err := c.cc.Invoke(ctx, "/graphservice.GraphService/MaxFlow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GraphServiceServer is the server API for GraphService service.
 // All implementations must embed UnimplementedGraphServiceServer
 // for forward compatibility
@@ -73,8 +323,50 @@ type GraphServiceServer interface {
 	PostGraph(context.Context, *Graph) (*GraphID, error)
 	// Find the shortest path
 	ShortestPath(context.Context, *PathRequest) (*Path, error)
+	// Find the k shortest paths between two vertices that visit no vertex twice
+	KShortestPaths(context.Context, *KPathsRequest) (*PathList, error)
+	// Find the shortest paths between many pairs of vertices of a graph
+	BatchShortestPath(context.Context, *BatchPathRequest) (*BatchPathReply, error)
+	// Find the cost of the shortest path between every pair of vertices,
+	// streamed one row of the distance matrix at a time
+	AllPairsShortestPaths(*AllPairsRequest, GraphService_AllPairsShortestPathsServer) error
+	// Find the shortest paths from one vertex to every vertex it reaches
+	ShortestPathTree(context.Context, *TreeRequest) (*PathTree, error)
 	// Delete the graph
 	DeleteGraph(context.Context, *GraphID) (*DeleteReply, error)
+	// Add vertices to a stored graph
+	AddVertices(context.Context, *VerticesRequest) (*MutationReply, error)
+	// Remove vertices, and the edges touching them, from a stored graph
+	RemoveVertices(context.Context, *VerticesRequest) (*MutationReply, error)
+	// Add edges to a stored graph, or change the weight of existing ones
+	AddEdges(context.Context, *EdgesRequest) (*MutationReply, error)
+	// Remove edges from a stored graph
+	RemoveEdges(context.Context, *EdgesRequest) (*MutationReply, error)
+	// Get a stored graph
+	GetGraph(context.Context, *GraphID) (*Graph, error)
+	// List the stored graphs, in order of ID
+	ListGraphs(context.Context, *ListGraphsRequest) (*ListGraphsReply, error)
+	// Get the counters of the shortest path cache
+	GetCacheStats(context.Context, *CacheStatsRequest) (*CacheStats, error)
+	// Preprocess a graph in the background for faster queries, streaming
+	// the progress until it is done
+	PrepareGraph(*PrepareRequest, GraphService_PrepareGraphServer) error
+	// Find the connected components of a graph
+	ConnectedComponents(context.Context, *GraphID) (*ComponentList, error)
+	// Tell whether there is a path from one vertex to another
+	IsReachable(context.Context, *ReachRequest) (*Reachability, error)
+	// Find the strongly connected components of a graph, and the DAG
+	// they condense it to
+	StronglyConnectedComponents(context.Context, *SCCRequest) (*SCCReply, error)
+	// Order the vertices of a directed acyclic graph so that every edge
+	// goes forward, or group them into layers
+	TopologicalSort(context.Context, *TopoRequest) (*TopoOrder, error)
+	// Find a minimum spanning tree of each connected component of an
+	// undirected graph
+	MinimumSpanningTree(context.Context, *MSTRequest) (*SpanningForest, error)
+	// Find a maximum flow between two vertices through the capacities
+	// of the edges, and a minimum cut between them
+	MaxFlow(context.Context, *FlowRequest) (*FlowReply, error)
 	mustEmbedUnimplementedGraphServiceServer()
 }
 
@@ -88,9 +380,63 @@ func (UnimplementedGraphServiceServer) PostGraph(context.Context, *Graph) (*Grap
 func (UnimplementedGraphServiceServer) ShortestPath(context.Context, *PathRequest) (*Path, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShortestPath not implemented")
 }
+func (UnimplementedGraphServiceServer) KShortestPaths(context.Context, *KPathsRequest) (*PathList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KShortestPaths not implemented")
+}
+func (UnimplementedGraphServiceServer) BatchShortestPath(context.Context, *BatchPathRequest) (*BatchPathReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchShortestPath not implemented")
+}
+func (UnimplementedGraphServiceServer) AllPairsShortestPaths(*AllPairsRequest, GraphService_AllPairsShortestPathsServer) error {
+	return status.Errorf(codes.Unimplemented, "method AllPairsShortestPaths not implemented")
+}
+func (UnimplementedGraphServiceServer) ShortestPathTree(context.Context, *TreeRequest) (*PathTree, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShortestPathTree not implemented")
+}
 func (UnimplementedGraphServiceServer) DeleteGraph(context.Context, *GraphID) (*DeleteReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteGraph not implemented")
 }
+func (UnimplementedGraphServiceServer) AddVertices(context.Context, *VerticesRequest) (*MutationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddVertices not implemented")
+}
+func (UnimplementedGraphServiceServer) RemoveVertices(context.Context, *VerticesRequest) (*MutationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveVertices not implemented")
+}
+func (UnimplementedGraphServiceServer) AddEdges(context.Context, *EdgesRequest) (*MutationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddEdges not implemented")
+}
+func (UnimplementedGraphServiceServer) RemoveEdges(context.Context, *EdgesRequest) (*MutationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveEdges not implemented")
+}
+func (UnimplementedGraphServiceServer) GetGraph(context.Context, *GraphID) (*Graph, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGraph not implemented")
+}
+func (UnimplementedGraphServiceServer) ListGraphs(context.Context, *ListGraphsRequest) (*ListGraphsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGraphs not implemented")
+}
+func (UnimplementedGraphServiceServer) GetCacheStats(context.Context, *CacheStatsRequest) (*CacheStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCacheStats not implemented")
+}
+func (UnimplementedGraphServiceServer) PrepareGraph(*PrepareRequest, GraphService_PrepareGraphServer) error {
+	return status.Errorf(codes.Unimplemented, "method PrepareGraph not implemented")
+}
+func (UnimplementedGraphServiceServer) ConnectedComponents(context.Context, *GraphID) (*ComponentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectedComponents not implemented")
+}
+func (UnimplementedGraphServiceServer) IsReachable(context.Context, *ReachRequest) (*Reachability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsReachable not implemented")
+}
+func (UnimplementedGraphServiceServer) StronglyConnectedComponents(context.Context, *SCCRequest) (*SCCReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StronglyConnectedComponents not implemented")
+}
+func (UnimplementedGraphServiceServer) TopologicalSort(context.Context, *TopoRequest) (*TopoOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopologicalSort not implemented")
+}
+func (UnimplementedGraphServiceServer) MinimumSpanningTree(context.Context, *MSTRequest) (*SpanningForest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MinimumSpanningTree not implemented")
+}
+func (UnimplementedGraphServiceServer) MaxFlow(context.Context, *FlowRequest) (*FlowReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MaxFlow not implemented")
+}
 func (UnimplementedGraphServiceServer) mustEmbedUnimplementedGraphServiceServer() {}
 
 // UnsafeGraphServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -140,6 +486,81 @@ func _GraphService_ShortestPath_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GraphService_KShortestPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KPathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).KShortestPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/KShortestPaths",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).KShortestPaths(ctx, req.(*KPathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_BatchShortestPath_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchPathRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).BatchShortestPath(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/BatchShortestPath",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).BatchShortestPath(ctx, req.(*BatchPathRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_AllPairsShortestPaths_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AllPairsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GraphServiceServer).AllPairsShortestPaths(m, &graphServiceAllPairsShortestPathsServer{stream})
+}
+
+type GraphService_AllPairsShortestPathsServer interface {
+	Send(*DistanceRow) error
+	grpc.ServerStream
+}
+
+type graphServiceAllPairsShortestPathsServer struct {
+	grpc.ServerStream
+}
+
+func (x *graphServiceAllPairsShortestPathsServer) Send(m *DistanceRow) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GraphService_ShortestPathTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ShortestPathTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/ShortestPathTree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ShortestPathTree(ctx, req.(*TreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GraphService_DeleteGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GraphID)
 	if err := dec(in); err != nil {
@@ -158,6 +579,261 @@ func _GraphService_DeleteGraph_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GraphService_AddVertices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerticesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).AddVertices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/AddVertices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).AddVertices(ctx, req.(*VerticesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_RemoveVertices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerticesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).RemoveVertices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/RemoveVertices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).RemoveVertices(ctx, req.(*VerticesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_AddEdges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EdgesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).AddEdges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/AddEdges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).AddEdges(ctx, req.(*EdgesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_RemoveEdges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EdgesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).RemoveEdges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/RemoveEdges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).RemoveEdges(ctx, req.(*EdgesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GraphID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/GetGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetGraph(ctx, req.(*GraphID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_ListGraphs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGraphsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ListGraphs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/ListGraphs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ListGraphs(ctx, req.(*ListGraphsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_GetCacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CacheStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).GetCacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/GetCacheStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).GetCacheStats(ctx, req.(*CacheStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_PrepareGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PrepareRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GraphServiceServer).PrepareGraph(m, &graphServicePrepareGraphServer{stream})
+}
+
+type GraphService_PrepareGraphServer interface {
+	Send(*PrepareProgress) error
+	grpc.ServerStream
+}
+
+type graphServicePrepareGraphServer struct {
+	grpc.ServerStream
+}
+
+func (x *graphServicePrepareGraphServer) Send(m *PrepareProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GraphService_ConnectedComponents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GraphID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).ConnectedComponents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/ConnectedComponents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).ConnectedComponents(ctx, req.(*GraphID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_IsReachable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReachRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).IsReachable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/IsReachable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).IsReachable(ctx, req.(*ReachRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_StronglyConnectedComponents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SCCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).StronglyConnectedComponents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/StronglyConnectedComponents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).StronglyConnectedComponents(ctx, req.(*SCCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_TopologicalSort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).TopologicalSort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/TopologicalSort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).TopologicalSort(ctx, req.(*TopoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_MinimumSpanningTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MSTRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).MinimumSpanningTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/MinimumSpanningTree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).MinimumSpanningTree(ctx, req.(*MSTRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GraphService_MaxFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GraphServiceServer).MaxFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/graphservice.GraphService/MaxFlow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GraphServiceServer).MaxFlow(ctx, req.(*FlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GraphService_ServiceDesc is the grpc.ServiceDesc for GraphService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -173,11 +849,86 @@ var GraphService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ShortestPath",
 			Handler:    _GraphService_ShortestPath_Handler,
 		},
+		{
+			MethodName: "KShortestPaths",
+			Handler:    _GraphService_KShortestPaths_Handler,
+		},
+		{
+			MethodName: "BatchShortestPath",
+			Handler:    _GraphService_BatchShortestPath_Handler,
+		},
+		{
+			MethodName: "ShortestPathTree",
+			Handler:    _GraphService_ShortestPathTree_Handler,
+		},
 		{
 			MethodName: "DeleteGraph",
 			Handler:    _GraphService_DeleteGraph_Handler,
 		},
+		{
+			MethodName: "AddVertices",
+			Handler:    _GraphService_AddVertices_Handler,
+		},
+		{
+			MethodName: "RemoveVertices",
+			Handler:    _GraphService_RemoveVertices_Handler,
+		},
+		{
+			MethodName: "AddEdges",
+			Handler:    _GraphService_AddEdges_Handler,
+		},
+		{
+			MethodName: "RemoveEdges",
+			Handler:    _GraphService_RemoveEdges_Handler,
+		},
+		{
+			MethodName: "GetGraph",
+			Handler:    _GraphService_GetGraph_Handler,
+		},
+		{
+			MethodName: "ListGraphs",
+			Handler:    _GraphService_ListGraphs_Handler,
+		},
+		{
+			MethodName: "GetCacheStats",
+			Handler:    _GraphService_GetCacheStats_Handler,
+		},
+		{
+			MethodName: "ConnectedComponents",
+			Handler:    _GraphService_ConnectedComponents_Handler,
+		},
+		{
+			MethodName: "IsReachable",
+			Handler:    _GraphService_IsReachable_Handler,
+		},
+		{
+			MethodName: "StronglyConnectedComponents",
+			Handler:    _GraphService_StronglyConnectedComponents_Handler,
+		},
+		{
+			MethodName: "TopologicalSort",
+			Handler:    _GraphService_TopologicalSort_Handler,
+		},
+		{
+			MethodName: "MinimumSpanningTree",
+			Handler:    _GraphService_MinimumSpanningTree_Handler,
+		},
+		{
+			MethodName: "MaxFlow",
+			Handler:    _GraphService_MaxFlow_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AllPairsShortestPaths",
+			Handler:       _GraphService_AllPairsShortestPaths_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PrepareGraph",
+			Handler:       _GraphService_PrepareGraph_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "graph.proto",
 }
@@ -0,0 +1,83 @@
+package main
+
+import (
+	graph "github.com/yc2454/Graph-Service/graph"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// The largest graph whose distances AllPairsShortestPaths finds with the
+// Floyd-Warshall algorithm, which holds the whole distance matrix in
+// memory. Johnson's algorithm finds one row at a time, on any graph
+const maxFloydWarshallVertices = 2000
+
+// AllPairsShortestPaths finds the cost of the shortest path between every
+// pair of vertices of a graph, and streams the distance matrix one row
+// per source vertex, in ascending order of ID.
+func (s *graphServiceServer) AllPairsShortestPaths(req *pb.AllPairsRequest, stream pb.GraphService_AllPairsShortestPathsServer) error {
+
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		return err
+	}
+	n := g.NodeCount()
+	if req.Algorithm == pb.AllPairsAlgorithm_FLOYD_WARSHALL && n > maxFloydWarshallVertices {
+		return allPairsLimitError(req.Gid.Id, n, maxFloydWarshallVertices)
+	}
+
+	// Unless one is requested, Floyd-Warshall is only used on dense
+	// graphs, where it beats Johnson's algorithm, and small enough
+	// for their matrix to be held
+	floyd := req.Algorithm == pb.AllPairsAlgorithm_FLOYD_WARSHALL ||
+		(req.Algorithm == pb.AllPairsAlgorithm_ALL_PAIRS_AUTO &&
+			g.EdgeCount() > n*n/4 && n <= maxFloydWarshallVertices)
+
+	var values []int
+	var row func(i int, dist []int)
+	if floyd {
+		m, err := g.FloydWarshall()
+		if err != nil {
+			return graphError(req.Gid.Id, err)
+		}
+		values = m.Values()
+		row = func(i int, dist []int) { copy(dist, m.Row(i)) }
+	} else {
+		js, err := g.JohnsonSearch()
+		if err != nil {
+			return graphError(req.Gid.Id, err)
+		}
+		values = js.Values()
+		row = js.Row
+	}
+
+	dist := make([]int, len(values))
+	for i, source := range values {
+		// Stop searching once the client is gone
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		row(i, dist)
+
+		res := &pb.DistanceRow{
+			Source:    int32(source),
+			Distances: make([]int64, len(values)),
+			Reachable: make([]bool, len(values)),
+		}
+		if i == 0 {
+			for _, v := range values {
+				res.Vertices = append(res.Vertices, int32(v))
+			}
+		}
+		for j, d := range dist {
+			if d != graph.Unreachable {
+				res.Distances[j] = int64(d)
+				res.Reachable[j] = true
+			}
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
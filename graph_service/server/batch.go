@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// The largest number of pairs in a BatchShortestPath request
+const maxBatchSize = 10000
+
+// BatchShortestPath finds the shortest path between each requested pair
+// of vertices, with one search per distinct source. A pair that fails,
+// such as one with a vertex not in the graph, gets an error in place of
+// its path, and does not fail the others.
+func (s *graphServiceServer) BatchShortestPath(ctx context.Context, req *pb.BatchPathRequest) (*pb.BatchPathReply, error) {
+
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Pairs) > maxBatchSize {
+		return nil, invalidRequestError("too many pairs", "pairs",
+			fmt.Sprintf("a batch holds at most %d pairs", maxBatchSize))
+	}
+
+	// Group the pairs by source, keeping the order the sources first
+	// appear in, so that each source is searched from once
+	var sources []int32
+	bySource := make(map[int32][]int)
+	for i, p := range req.Pairs {
+		if _, ok := bySource[p.GetS()]; !ok {
+			sources = append(sources, p.GetS())
+		}
+		bySource[p.GetS()] = append(bySource[p.GetS()], i)
+	}
+
+	res := &pb.BatchPathReply{Results: make([]*pb.PathResult, len(req.Pairs))}
+	for _, source := range sources {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+
+		var tree *graph.PathTree
+		treeErr := vertexNotFoundError(req.Gid.Id, source)
+		if n, err := g.FindNode(int(source)); err == nil {
+			if tree, err = s.pathTree(req.Gid.Id, g, n); err != nil {
+				treeErr = graphError(req.Gid.Id, err)
+			}
+		}
+		for _, i := range bySource[source] {
+			res.Results[i] = pathResult(req.Gid, req.Pairs[i], tree, treeErr, g)
+		}
+	}
+	return res, nil
+}
+
+// pathResult looks up the path for [pair] in [tree], the tree of its
+// source, or reports [treeErr] if there is no tree
+func pathResult(gid *pb.GraphID, pair *pb.VertexPair, tree *graph.PathTree, treeErr error, g *graph.ItemGraph) *pb.PathResult {
+	var err error
+	switch {
+	case tree == nil:
+		err = treeErr
+	case !hasVertex(g, pair.GetT()):
+		err = vertexNotFoundError(gid.Id, pair.GetT())
+	default:
+		p, cost, perr := tree.PathTo(int(pair.GetT()))
+		if perr == nil {
+			path := &pb.Path{Cost: int64(cost)}
+			for _, n := range p {
+				path.Path = append(path.Path, int32(n))
+			}
+			return &pb.PathResult{Result: &pb.PathResult_Path{Path: path}}
+		}
+		err = noPathError(&pb.PathRequest{Gid: gid, S: pair.GetS(), T: pair.GetT()})
+	}
+
+	st := status.Convert(err).Proto()
+	return &pb.PathResult{Result: &pb.PathResult_Error{Error: &pb.PathError{
+		Code:    st.Code,
+		Message: st.Message,
+		Details: st.Details,
+	}}}
+}
+
+// hasVertex tells whether [g] has the vertex [v]
+func hasVertex(g *graph.ItemGraph, v int32) bool {
+	_, err := g.FindNode(int(v))
+	return err == nil
+}
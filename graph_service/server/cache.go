@@ -0,0 +1,257 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// The number of shortest path trees cached by default
+const defaultPathCacheSize = 128
+
+// pathKey identifies the shortest path tree from one source of a graph
+type pathKey struct {
+	gid    int32
+	source int32
+}
+
+type pathEntry struct {
+	key  pathKey
+	tree *graph.PathTree
+
+	// The number of times the tree was used, and its
+	// place in the list of entries used as many times
+	freq int
+	elem *list.Element
+}
+
+// pathCacheStats counts the lookups of a pathCache
+type pathCacheStats struct {
+	hits, misses, evictions uint64
+	size, capacity          int
+}
+
+// pathCache is a bounded cache of shortest path trees. When it is full,
+// the least frequently used tree is evicted, the least recently used
+// among equals. The trees of a graph are dropped when it changes
+type pathCache struct {
+	capacity int
+	entries  map[pathKey]*pathEntry
+
+	// The entries used the same number of times, least recently
+	// used first, and the lowest such number
+	freqs   map[int]*list.List
+	minFreq int
+
+	// The sources of the cached trees of each graph
+	byGraph map[int32]map[int32]bool
+
+	// Counts the changes of all graphs, so that a tree computed
+	// before a change is not cached after it
+	gen uint64
+
+	hits, misses, evictions uint64
+
+	mu sync.Mutex
+}
+
+// Constructor of a pathCache holding up to [capacity] trees
+func newPathCache(capacity int) *pathCache {
+	return &pathCache{
+		capacity: capacity,
+		entries:  make(map[pathKey]*pathEntry),
+		freqs:    make(map[int]*list.List),
+		byGraph:  make(map[int32]map[int32]bool),
+	}
+}
+
+// get returns the tree from [source] in graph [gid], or nil
+func (c *pathCache) get(gid, source int32) *graph.PathTree {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[pathKey{gid, source}]
+	if !ok {
+		c.misses++
+		return nil
+	}
+	c.hits++
+	c.touch(e)
+	return e.tree
+}
+
+// generation returns the number of changes of the graphs so far.
+// It must be read before computing a tree to put in the cache
+func (c *pathCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+// put caches [tree], the tree from [source] in graph [gid] computed
+// at generation [gen]. It is dropped if any graph has changed since
+func (c *pathCache) put(gid, source int32, gen uint64, tree *graph.PathTree) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.capacity <= 0 || c.gen != gen {
+		return
+	}
+	key := pathKey{gid, source}
+	if e, ok := c.entries[key]; ok {
+		e.tree = tree
+		c.touch(e)
+		return
+	}
+	if len(c.entries) >= c.capacity {
+		c.evict()
+	}
+
+	e := &pathEntry{key: key, tree: tree, freq: 1}
+	e.elem = c.list(1).PushBack(e)
+	c.entries[key] = e
+	c.minFreq = 1
+	if c.byGraph[gid] == nil {
+		c.byGraph[gid] = make(map[int32]bool)
+	}
+	c.byGraph[gid][source] = true
+}
+
+// invalidate drops the trees of graph [gid], which has changed
+func (c *pathCache) invalidate(gid int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	for source := range c.byGraph[gid] {
+		c.remove(c.entries[pathKey{gid, source}])
+	}
+	delete(c.byGraph, gid)
+}
+
+// stats returns the counters of the cache
+func (c *pathCache) stats() pathCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return pathCacheStats{
+		hits:      c.hits,
+		misses:    c.misses,
+		evictions: c.evictions,
+		size:      len(c.entries),
+		capacity:  c.capacity,
+	}
+}
+
+// touch counts a use of [e]
+func (c *pathCache) touch(e *pathEntry) {
+	c.unlink(e)
+	if e.freq == c.minFreq && c.freqs[e.freq] == nil {
+		c.minFreq++
+	}
+	e.freq++
+	e.elem = c.list(e.freq).PushBack(e)
+}
+
+// evict drops the least frequently used entry
+func (c *pathCache) evict() {
+	l := c.freqs[c.minFreq]
+	if l == nil {
+		// The lowest count is stale after an invalidation
+		for f := range c.freqs {
+			if l == nil || f < c.minFreq {
+				c.minFreq, l = f, c.freqs[f]
+			}
+		}
+		if l == nil {
+			return
+		}
+	}
+	c.remove(l.Front().Value.(*pathEntry))
+	c.evictions++
+}
+
+// remove drops [e] from the cache
+func (c *pathCache) remove(e *pathEntry) {
+	c.unlink(e)
+	delete(c.entries, e.key)
+	if sources := c.byGraph[e.key.gid]; sources != nil {
+		delete(sources, e.key.source)
+		if len(sources) == 0 {
+			delete(c.byGraph, e.key.gid)
+		}
+	}
+}
+
+// unlink takes [e] out of the list of entries used as many times
+func (c *pathCache) unlink(e *pathEntry) {
+	l := c.freqs[e.freq]
+	l.Remove(e.elem)
+	if l.Len() == 0 {
+		delete(c.freqs, e.freq)
+	}
+}
+
+// list returns the list of entries used [freq] times
+func (c *pathCache) list(freq int) *list.List {
+	l, ok := c.freqs[freq]
+	if !ok {
+		l = list.New()
+		c.freqs[freq] = l
+	}
+	return l
+}
+
+// scaleKey identifies the scale of one A* heuristic for a graph
+type scaleKey struct {
+	gid       int32
+	heuristic pb.PathHeuristic
+}
+
+// scaleCache holds the scales of the A* heuristics of each graph, which
+// take a pass over all its edges to find. Those of a graph are dropped
+// when it changes
+type scaleCache struct {
+	entries map[scaleKey]float64
+
+	// Counts the changes of all graphs, so that a scale
+	// found before a change is not cached after it
+	gen uint64
+
+	mu sync.Mutex
+}
+
+func newScaleCache() *scaleCache {
+	return &scaleCache{entries: make(map[scaleKey]float64)}
+}
+
+// get returns the scale of heuristic [h] for graph [gid] and whether it
+// is cached, along with the number of changes of the graphs so far
+func (c *scaleCache) get(gid int32, h pb.PathHeuristic) (float64, bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	scale, ok := c.entries[scaleKey{gid, h}]
+	return scale, ok, c.gen
+}
+
+// put caches [scale], the scale of heuristic [h] for graph [gid] found
+// at generation [gen]. It is dropped if any graph has changed since
+func (c *scaleCache) put(gid int32, h pb.PathHeuristic, gen uint64, scale float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen == gen {
+		c.entries[scaleKey{gid, h}] = scale
+	}
+}
+
+// invalidate drops the scales of graph [gid], which has changed
+func (c *scaleCache) invalidate(gid int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	for h := range pb.PathHeuristic_name {
+		delete(c.entries, scaleKey{gid, pb.PathHeuristic(h)})
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// ConnectedComponents returns the connected components of the graph
+// stored with ID=[id], in order of their lowest vertex.
+func (s *graphServiceServer) ConnectedComponents(ctx context.Context, id *pb.GraphID) (*pb.ComponentList, error) {
+
+	g, err := s.getGraph(id, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ComponentList{Components: componentMessages(s.components(id.Id, g))}, nil
+}
+
+// componentMessages lists the vertices of each of the components [c]
+func componentMessages(c *graph.Components) []*pb.Component {
+	res := make([]*pb.Component, c.Count())
+	for k := range res {
+		res[k] = &pb.Component{}
+		for _, v := range c.Members(k) {
+			res[k].Vertices = append(res[k].Vertices, int32(v))
+		}
+	}
+	return res
+}
+
+// IsReachable tells whether the graph has a path from the requested
+// start to the requested end. In an undirected graph, this is answered
+// from the cached components. In a directed graph, vertices in different
+// components are told apart the same way, and the others are searched.
+func (s *graphServiceServer) IsReachable(ctx context.Context, req *pb.ReachRequest) (*pb.Reachability, error) {
+
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		return nil, err
+	}
+	n1, err := g.FindNode(int(req.S))
+	if err != nil {
+		return nil, vertexNotFoundError(req.Gid.Id, req.S)
+	}
+	n2, err := g.FindNode(int(req.T))
+	if err != nil {
+		return nil, vertexNotFoundError(req.Gid.Id, req.T)
+	}
+
+	res := &pb.Reachability{}
+	res.Connected = s.components(req.Gid.Id, g).Connected(n1.Value(), n2.Value())
+	res.Reachable = res.Connected
+	if res.Connected && g.Directed() {
+		res.Reachable = g.Reachable(n1, n2)
+	}
+	return res, nil
+}
+
+// StronglyConnectedComponents returns the strongly connected components
+// of the requested graph, in topological order, and the graph they
+// condense it to, which is stored as a new graph if requested.
+func (s *graphServiceServer) StronglyConnectedComponents(ctx context.Context, req *pb.SCCRequest) (*pb.SCCReply, error) {
+
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		return nil, err
+	}
+
+	c, dag := g.Condensation()
+	res := &pb.SCCReply{
+		Components:   componentMessages(c),
+		Condensation: graphMessage(dag),
+	}
+
+	if req.StoreCondensation {
+		if res.CondensationId, err = s.storeGraph(dag); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// components returns the connected components of [g], the graph
+// with ID=[gid], from the cache if they are there
+func (s *graphServiceServer) components(gid int32, g *graph.ItemGraph) *graph.Components {
+	c, gen := s.labels.get(gid)
+	if c == nil {
+		c = g.ConnectedComponents()
+		s.labels.put(gid, gen, c)
+	}
+	return c
+}
+
+// componentCache holds the connected components of each graph.
+// Those of a graph are dropped when it changes
+type componentCache struct {
+	entries map[int32]*graph.Components
+
+	// Counts the changes of all graphs, so that components
+	// found before a change are not cached after it
+	gen uint64
+
+	mu sync.Mutex
+}
+
+func newComponentCache() *componentCache {
+	return &componentCache{entries: make(map[int32]*graph.Components)}
+}
+
+// get returns the components of graph [gid], or nil, along with the
+// number of changes of the graphs so far
+func (c *componentCache) get(gid int32) (*graph.Components, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[gid], c.gen
+}
+
+// put caches [comps], the components of graph [gid] found at
+// generation [gen]. They are dropped if any graph has changed since
+func (c *componentCache) put(gid int32, gen uint64, comps *graph.Components) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen == gen {
+		c.entries[gid] = comps
+	}
+}
+
+// invalidate drops the components of graph [gid], which has changed
+func (c *componentCache) invalidate(gid int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	delete(c.entries, gid)
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// The domain of the ErrorInfo details returned by the service
+const errorDomain = "graphservice"
+
+// statusError builds a gRPC error with the given code and message,
+// carrying [details] so that clients can tell failures apart
+// without parsing the message
+func statusError(c codes.Code, msg string, details ...protoiface.MessageV1) error {
+	st := status.New(c, msg)
+	if ds, err := st.WithDetails(details...); err == nil {
+		st = ds
+	}
+	return st.Err()
+}
+
+// graphName is how the graph with ID [id] is named in error details
+func graphName(id int32) string {
+	return fmt.Sprintf("graphs/%d", id)
+}
+
+// missingGraphIDError reports a request without a graph ID
+func missingGraphIDError(field string) error {
+	return statusError(codes.InvalidArgument, "missing graph ID",
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: "a graph ID is required"},
+		}})
+}
+
+// graphNotFoundError reports that no graph is stored with ID [id]
+func graphNotFoundError(id int32) error {
+	return statusError(codes.NotFound, "non-existant graph",
+		&errdetails.ResourceInfo{
+			ResourceType: "graph",
+			ResourceName: graphName(id),
+			Description:  "no graph is stored with this ID",
+		})
+}
+
+// vertexNotFoundError reports that the graph with ID [id] has no vertex [v]
+func vertexNotFoundError(id int32, v int32) error {
+	return statusError(codes.NotFound, "non-existant node",
+		&errdetails.ResourceInfo{
+			ResourceType: "vertex",
+			ResourceName: strconv.Itoa(int(v)),
+			Owner:        graphName(id),
+			Description:  "the graph has no such vertex",
+		})
+}
+
+// vertexExistsError reports that the graph with ID [id] already has vertex [v]
+func vertexExistsError(id int32, v int32) error {
+	return statusError(codes.AlreadyExists, "vertex already exists",
+		&errdetails.ResourceInfo{
+			ResourceType: "vertex",
+			ResourceName: strconv.Itoa(int(v)),
+			Owner:        graphName(id),
+			Description:  "the graph already has this vertex",
+		})
+}
+
+// edgeNotFoundError reports that the graph with ID [id] has no edge
+// from [v1] to [v2]
+func edgeNotFoundError(id int32, v1 int32, v2 int32) error {
+	return statusError(codes.NotFound, "non-existant edge",
+		&errdetails.ResourceInfo{
+			ResourceType: "edge",
+			ResourceName: fmt.Sprintf("%d->%d", v1, v2),
+			Owner:        graphName(id),
+			Description:  "the graph has no such edge",
+		})
+}
+
+// allPairsLimitError reports that the graph with ID [id] has [n]
+// vertices, more than the [limit] of the Floyd-Warshall algorithm
+func allPairsLimitError(id int32, n, limit int) error {
+	return statusError(codes.FailedPrecondition,
+		fmt.Sprintf("graph has %d vertices, but Floyd-Warshall is limited to %d", n, limit),
+		&errdetails.ResourceInfo{
+			ResourceType: "graph",
+			ResourceName: graphName(id),
+			Description:  fmt.Sprintf("Floyd-Warshall is limited to graphs of %d vertices; Johnson's algorithm has no limit", limit),
+		})
+}
+
+// graphError converts an error of the graph package, raised by the
+// graph with ID [id], into a gRPC error
+func graphError(id int32, err error) error {
+	var dup *graph.DuplicateNodeError
+	var missing *graph.NodeNotFoundError
+	var noEdge *graph.EdgeNotFoundError
+	var cycle *graph.NegativeCycleError
+	var loop *graph.CycleError
+
+	switch {
+	case errors.As(err, &dup):
+		return vertexExistsError(id, int32(dup.Value))
+	case errors.As(err, &missing):
+		return vertexNotFoundError(id, int32(missing.Value))
+	case errors.As(err, &noEdge):
+		return edgeNotFoundError(id, int32(noEdge.From), int32(noEdge.To))
+	case errors.As(err, &cycle):
+		nc := &pb.NegativeCycle{Gid: &pb.GraphID{Id: id}}
+		for _, v := range cycle.Cycle {
+			nc.Vertices = append(nc.Vertices, int32(v))
+		}
+		return statusError(codes.FailedPrecondition, "graph has a negative cycle",
+			&errdetails.ResourceInfo{ResourceType: "graph", ResourceName: graphName(id)}, nc)
+	case errors.As(err, &loop):
+		c := &pb.Cycle{Gid: &pb.GraphID{Id: id}}
+		for _, v := range loop.Cycle {
+			c.Vertices = append(c.Vertices, int32(v))
+		}
+		return statusError(codes.FailedPrecondition, "graph has a cycle",
+			&errdetails.ResourceInfo{ResourceType: "graph", ResourceName: graphName(id),
+				Description: "the request needs a graph without cycles"}, c)
+	case errors.Is(err, graph.ErrUndirected):
+		return statusError(codes.FailedPrecondition, "graph is not directed",
+			&errdetails.ResourceInfo{ResourceType: "graph", ResourceName: graphName(id),
+				Description: "the request needs a directed graph"})
+	case errors.Is(err, graph.ErrDirected):
+		return statusError(codes.FailedPrecondition, "graph is directed",
+			&errdetails.ResourceInfo{ResourceType: "graph", ResourceName: graphName(id),
+				Description: "the request needs an undirected graph"})
+	case errors.Is(err, graph.ErrNegativeWeights):
+		return statusError(codes.FailedPrecondition, "graph has negative weights",
+			&errdetails.ResourceInfo{ResourceType: "graph", ResourceName: graphName(id),
+				Description: "the request needs non-negative edge weights"})
+	case errors.Is(err, graph.ErrNegativeCycle):
+		return statusError(codes.FailedPrecondition, "graph has a negative cycle",
+			&errdetails.ResourceInfo{ResourceType: "graph", ResourceName: graphName(id)})
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// storageError reports a failure of the store of the server, which
+// may succeed if the request is retried. The cause is only logged
+func storageError(err error) error {
+	log.Printf("graph store failed: %v", err)
+	return status.Error(codes.Unavailable, "cannot access the stored graphs")
+}
+
+// invalidRequestError reports a request that is malformed at [field]
+func invalidRequestError(msg string, field string, desc string) error {
+	return statusError(codes.InvalidArgument, msg,
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: desc},
+		}})
+}
+
+// negativeWeightError reports an edge of negative weight, at [field],
+// in an undirected graph, where it would be a negative cycle
+func negativeWeightError(field string) error {
+	return invalidRequestError("found edge with negative weight",
+		field, "only the edges of a directed graph may have negative weights")
+}
+
+// negativeCapacityError reports an edge of negative capacity at [field]
+func negativeCapacityError(field string) error {
+	return invalidRequestError("found edge with negative capacity",
+		field, "the capacity of an edge must be zero or more")
+}
+
+// noPathError reports that the end of the requested path cannot be
+// reached from its start, with the request attached as an ErrorInfo
+func noPathError(req *pb.PathRequest) error {
+	return statusError(codes.NotFound, "no path between nodes",
+		&errdetails.ErrorInfo{
+			Reason: "NO_PATH",
+			Domain: errorDomain,
+			Metadata: map[string]string{
+				"graph_id": strconv.Itoa(int(req.GetGid().GetId())),
+				"source":   strconv.Itoa(int(req.S)),
+				"target":   strconv.Itoa(int(req.T)),
+			},
+		})
+}
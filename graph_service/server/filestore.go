@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// fileStore is a GraphStore that keeps the graphs in memory, and every
+// change to them in a write-ahead log on disk, from which they are
+// recovered when the store is opened again
+type fileStore struct {
+	mem *memoryStore
+	log *graphLog
+
+	// Serializes the changes to the graphs, so that they are
+	// applied in the order they are written to the log
+	mu sync.Mutex
+}
+
+// openFileStore opens the store kept in [dir], taking a snapshot of the
+// graphs every [snapshotEvery] changes. The graphs already in [dir] are
+// recovered first
+func openFileStore(dir string, snapshotEvery int) (*fileStore, error) {
+	l, snap, recs, err := openGraphLog(dir, snapshotEvery)
+	if err != nil {
+		return nil, err
+	}
+	fs := &fileStore{mem: newMemoryStore(), log: l}
+	if err := fs.recover(snap, recs); err != nil {
+		l.close()
+		return nil, err
+	}
+	return fs, nil
+}
+
+func (fs *fileStore) Get(id int32) (*storedGraph, error) {
+	return fs.mem.Get(id)
+}
+
+func (fs *fileStore) Put(sg *storedGraph) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	rec := &pb.LogRecord{Op: &pb.LogRecord_PostGraph{PostGraph: &pb.StoredGraph{
+		Gid:       &pb.GraphID{Id: sg.id},
+		Graph:     graphMessage(sg.g),
+		CreatedAt: timestamppb.New(sg.created),
+	}}}
+	if err := fs.log.append(rec); err != nil {
+		return err
+	}
+	fs.mem.Put(sg)
+	fs.maybeSnapshot()
+	return nil
+}
+
+func (fs *fileStore) Delete(id int32) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if _, err := fs.mem.Get(id); err != nil {
+		return err
+	}
+	rec := &pb.LogRecord{Op: &pb.LogRecord_DeleteGraph{DeleteGraph: &pb.GraphID{Id: id}}}
+	if err := fs.log.append(rec); err != nil {
+		return err
+	}
+	fs.mem.Delete(id)
+	fs.maybeSnapshot()
+	return nil
+}
+
+func (fs *fileStore) List(start int32, limit int) ([]*storedGraph, error) {
+	return fs.mem.List(start, limit)
+}
+
+func (fs *fileStore) NextID() (int32, error) {
+	return fs.mem.NextID()
+}
+
+// Mutate writes [rec] to the log before calling [apply]. A failed
+// apply leaves the record in the log, so it must only be called for
+// mutations already checked against their graph
+func (fs *fileStore) Mutate(rec *pb.LogRecord, apply func() error) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if err := fs.log.append(rec); err != nil {
+		return err
+	}
+	if err := apply(); err != nil {
+		return err
+	}
+	fs.maybeSnapshot()
+	return nil
+}
+
+// Close closes the log of the store
+func (fs *fileStore) Close() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.log.close()
+}
+
+// maybeSnapshot takes a snapshot of the graphs once enough changes have
+// been logged since the last one. A failed snapshot is only reported,
+// since the changes are still in the log. The caller must hold mu
+func (fs *fileStore) maybeSnapshot() {
+	l := fs.log
+	if l.snapshotEvery <= 0 || l.sinceSnapshot < l.snapshotEvery {
+		return
+	}
+	if err := l.writeSnapshot(fs.snapshot()); err != nil {
+		log.Printf("failed to take a snapshot: %v", err)
+	}
+}
+
+// snapshot describes the stored graphs as of the last logged change.
+// The caller must hold mu
+func (fs *fileStore) snapshot() *pb.Snapshot {
+	graphs, next := fs.mem.all()
+	snap := &pb.Snapshot{Seq: fs.log.seq, NextId: next}
+	for _, sg := range graphs {
+		snap.Graphs = append(snap.Graphs, &pb.StoredGraph{
+			Gid:       &pb.GraphID{Id: sg.id},
+			Graph:     graphMessage(sg.g),
+			CreatedAt: timestamppb.New(sg.created),
+		})
+	}
+	return snap
+}
+
+// recover restores the graphs from [snap] and the records of the log
+// written after it
+func (fs *fileStore) recover(snap *pb.Snapshot, recs []*pb.LogRecord) error {
+	for _, sg := range snap.Graphs {
+		g, err := buildGraph(sg.Graph)
+		if err != nil {
+			return fmt.Errorf("graph %d of the snapshot: %v", sg.Gid.GetId(), err)
+		}
+		fs.mem.Put(&storedGraph{id: sg.Gid.GetId(), g: g, created: sg.CreatedAt.AsTime()})
+	}
+
+	// Deleted graphs may have had the highest IDs
+	fs.mem.reserveIDs(snap.NextId)
+
+	for _, rec := range recs {
+		if err := fs.replay(rec); err != nil {
+			return fmt.Errorf("log record %d: %v", rec.Seq, err)
+		}
+	}
+	return nil
+}
+
+// replay applies the change logged in [rec]
+func (fs *fileStore) replay(rec *pb.LogRecord) error {
+	switch op := rec.Op.(type) {
+	case *pb.LogRecord_PostGraph:
+		g, err := buildGraph(op.PostGraph.Graph)
+		if err != nil {
+			return err
+		}
+		return fs.mem.Put(&storedGraph{id: op.PostGraph.Gid.GetId(), g: g, created: op.PostGraph.CreatedAt.AsTime()})
+
+	case *pb.LogRecord_DeleteGraph:
+		return fs.mem.Delete(op.DeleteGraph.GetId())
+	}
+
+	m, err := parseMutation(rec)
+	if err != nil {
+		return err
+	}
+	sg, err := fs.mem.Get(m.gid.GetId())
+	if err != nil {
+		return err
+	}
+	return m.apply(sg.g)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// The largest number of paths KShortestPaths finds
+const maxPathsK = 100
+
+// KShortestPaths finds up to k paths between the requested vertices that
+// visit no vertex twice, in order of cost, as alternatives to the
+// shortest one.
+func (s *graphServiceServer) KShortestPaths(ctx context.Context, req *pb.KPathsRequest) (*pb.PathList, error) {
+
+	if req.K <= 0 || req.K > maxPathsK {
+		return nil, invalidRequestError("invalid number of paths", "k",
+			fmt.Sprintf("k must be between 1 and %d", maxPathsK))
+	}
+
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		return nil, err
+	}
+	if g.HasNegativeWeights() {
+		return nil, graphError(req.Gid.Id, graph.ErrNegativeWeights)
+	}
+
+	n1, err1 := g.FindNode(int(req.S))
+	if err1 != nil {
+		return nil, vertexNotFoundError(req.Gid.Id, req.S)
+	}
+	n2, err2 := g.FindNode(int(req.T))
+	if err2 != nil {
+		return nil, vertexNotFoundError(req.Gid.Id, req.T)
+	}
+
+	paths, err := g.KShortestPaths(n1, n2, int(req.K))
+	if err != nil {
+		return nil, noPathError(&pb.PathRequest{Gid: req.Gid, S: req.S, T: req.T})
+	}
+
+	res := new(pb.PathList)
+	for _, p := range paths {
+		path := &pb.Path{Cost: int64(p.Cost)}
+		for _, n := range p.Nodes {
+			path.Path = append(path.Path, int32(n))
+		}
+		res.Paths = append(res.Paths, path)
+	}
+	return res, nil
+}
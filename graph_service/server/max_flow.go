@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// MaxFlow returns a maximum flow from the requested source to the
+// requested sink through the capacities of the edges, the flow along
+// each edge carrying any, and the two sides of a minimum cut.
+func (s *graphServiceServer) MaxFlow(ctx context.Context, req *pb.FlowRequest) (*pb.FlowReply, error) {
+
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		return nil, err
+	}
+	source, err := g.FindNode(int(req.Source))
+	if err != nil {
+		return nil, vertexNotFoundError(req.Gid.Id, req.Source)
+	}
+	sink, err := g.FindNode(int(req.Sink))
+	if err != nil {
+		return nil, vertexNotFoundError(req.Gid.Id, req.Sink)
+	}
+	if req.Source == req.Sink {
+		return nil, invalidRequestError("source and sink are the same vertex",
+			"sink", "the sink must be another vertex than the source")
+	}
+
+	var f *graph.Flow
+	if req.Algorithm == pb.FlowAlgorithm_PUSH_RELABEL {
+		f, err = g.PushRelabel(source, sink)
+	} else {
+		f, err = g.Dinic(source, sink)
+	}
+	if err != nil {
+		return nil, graphError(req.Gid.Id, err)
+	}
+
+	res := &pb.FlowReply{Value: int64(f.Value)}
+	for _, e := range f.Edges {
+		res.Edges = append(res.Edges, &pb.EdgeFlow{
+			V1:   &pb.Vertex{Id: int32(e.From)},
+			V2:   &pb.Vertex{Id: int32(e.To)},
+			Flow: int64(e.Flow),
+		})
+	}
+	for _, v := range f.SourceSide {
+		res.SourceSide = append(res.SourceSide, int32(v))
+	}
+	for _, v := range f.SinkSide {
+		res.SinkSide = append(res.SinkSide, int32(v))
+	}
+	return res, nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// AddVertices adds the requested vertices to a stored graph. If any of
+// them is already in the graph, none is added.
+func (s *graphServiceServer) AddVertices(ctx context.Context, req *pb.VerticesRequest) (*pb.MutationReply, error) {
+	return s.mutate(&pb.LogRecord{Op: &pb.LogRecord_AddVertices{AddVertices: req}})
+}
+
+// RemoveVertices removes the requested vertices, and every edge touching
+// them, from a stored graph. If any of them is not in the graph, none
+// is removed.
+func (s *graphServiceServer) RemoveVertices(ctx context.Context, req *pb.VerticesRequest) (*pb.MutationReply, error) {
+	return s.mutate(&pb.LogRecord{Op: &pb.LogRecord_RemoveVertices{RemoveVertices: req}})
+}
+
+// AddEdges adds the requested edges to a stored graph, replacing the
+// weight of those already in it. If an end of any edge is not in the
+// graph, no edge is added.
+func (s *graphServiceServer) AddEdges(ctx context.Context, req *pb.EdgesRequest) (*pb.MutationReply, error) {
+	return s.mutate(&pb.LogRecord{Op: &pb.LogRecord_AddEdges{AddEdges: req}})
+}
+
+// RemoveEdges removes the requested edges from a stored graph. If any
+// of them is not in the graph, none is removed.
+func (s *graphServiceServer) RemoveEdges(ctx context.Context, req *pb.EdgesRequest) (*pb.MutationReply, error) {
+	return s.mutate(&pb.LogRecord{Op: &pb.LogRecord_RemoveEdges{RemoveEdges: req}})
+}
+
+// mutation is a change to the vertices or edges of a stored graph
+type mutation struct {
+	gid *pb.GraphID
+
+	// check returns the error that apply would return, without
+	// changing the graph
+	check func(g *graph.ItemGraph) error
+	apply func(g *graph.ItemGraph) error
+}
+
+// parseMutation returns the mutation held by [rec], checking the
+// parts of it that do not depend on the graph
+func parseMutation(rec *pb.LogRecord) (*mutation, error) {
+	switch op := rec.Op.(type) {
+	case *pb.LogRecord_AddVertices:
+		values := vertexValues(op.AddVertices.Vertices)
+		positions, err := nodePositions(op.AddVertices.Positions, op.AddVertices.Vertices)
+		if err != nil {
+			return nil, err
+		}
+		return &mutation{
+			gid:   op.AddVertices.Gid,
+			check: func(g *graph.ItemGraph) error { return g.CheckAddNodes(values) },
+			apply: func(g *graph.ItemGraph) error { return g.AddNodesAt(values, positions) },
+		}, nil
+
+	case *pb.LogRecord_RemoveVertices:
+		values := vertexValues(op.RemoveVertices.Vertices)
+		return &mutation{
+			gid:   op.RemoveVertices.Gid,
+			check: func(g *graph.ItemGraph) error { return g.CheckRemoveNodes(values) },
+			apply: func(g *graph.ItemGraph) error { return g.RemoveNodes(values) },
+		}, nil
+
+	case *pb.LogRecord_AddEdges:
+		edges, err := edgeSpecs(op.AddEdges.Edges)
+		if err != nil {
+			return nil, err
+		}
+		capacities, err := edgeCapacities(op.AddEdges.Edges)
+		if err != nil {
+			return nil, err
+		}
+		return &mutation{
+			gid: op.AddEdges.Gid,
+			check: func(g *graph.ItemGraph) error {
+				for i, e := range edges {
+					if e.Weight < 0 && !g.Directed() {
+						return negativeWeightError(fmt.Sprintf("edges[%d].weight", i))
+					}
+				}
+				return g.CheckAddEdges(edges)
+			},
+			apply: func(g *graph.ItemGraph) error { return g.AddEdgesWithCapacity(edges, capacities) },
+		}, nil
+
+	case *pb.LogRecord_RemoveEdges:
+		edges, err := edgeSpecs(op.RemoveEdges.Edges)
+		if err != nil {
+			return nil, err
+		}
+		return &mutation{
+			gid:   op.RemoveEdges.Gid,
+			check: func(g *graph.ItemGraph) error { return g.CheckRemoveEdges(edges) },
+			apply: func(g *graph.ItemGraph) error { return g.RemoveEdges(edges) },
+		}, nil
+	}
+	return nil, fmt.Errorf("log record %d is not a mutation", rec.Seq)
+}
+
+// mutate checks the mutation held by [rec] against its graph and
+// applies it, through the store if it logs mutations. Nothing is
+// logged or changed if the mutation is invalid
+func (s *graphServiceServer) mutate(rec *pb.LogRecord) (*pb.MutationReply, error) {
+
+	m, err := parseMutation(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+
+	g, err := s.getGraph(m.gid, "gid")
+	if err != nil {
+		return nil, err
+	}
+	if err := m.check(g); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, graphError(m.gid.Id, err)
+	}
+
+	var applyErr error
+	apply := func() error {
+		applyErr = m.apply(g)
+		return applyErr
+	}
+	if ml, ok := s.store.(mutationLogger); ok {
+		err = ml.Mutate(rec, apply)
+	} else {
+		err = apply()
+	}
+	if applyErr != nil {
+		return nil, graphError(m.gid.Id, applyErr)
+	}
+	if err != nil {
+		return nil, storageError(err)
+	}
+	s.invalidate(m.gid.Id)
+	return mutationReply(g), nil
+}
+
+// vertexValues converts vertex IDs to node values
+func vertexValues(vertices []int32) []int {
+	values := make([]int, len(vertices))
+	for i, v := range vertices {
+		values[i] = int(v)
+	}
+	return values
+}
+
+// edgeSpecs converts the edges of a request to the graph package.
+// Both ends of every edge must be set
+func edgeSpecs(edges []*pb.Edge) ([]graph.EdgeSpec, error) {
+	specs := make([]graph.EdgeSpec, len(edges))
+	for i, e := range edges {
+		if e.GetV1() == nil || e.GetV2() == nil {
+			return nil, invalidRequestError("found edge without both ends",
+				fmt.Sprintf("edges[%d]", i), "both v1 and v2 must be set")
+		}
+		specs[i] = graph.EdgeSpec{From: int(e.V1.Id), To: int(e.V2.Id), Weight: int(e.Weight)}
+	}
+	return specs, nil
+}
+
+// edgeCapacities lists the capacities of the edges of a request,
+// none of which may be negative
+func edgeCapacities(edges []*pb.Edge) ([]int, error) {
+	capacities := make([]int, len(edges))
+	for i, e := range edges {
+		if e.Capacity < 0 {
+			return nil, negativeCapacityError(fmt.Sprintf("edges[%d].capacity", i))
+		}
+		capacities[i] = int(e.Capacity)
+	}
+	return capacities, nil
+}
+
+// mutationReply reports the size of [g] after a mutation
+func mutationReply(g *graph.ItemGraph) *pb.MutationReply {
+	return &pb.MutationReply{
+		VertexCount: int32(g.NodeCount()),
+		EdgeCount:   int32(g.EdgeCount()),
+	}
+}
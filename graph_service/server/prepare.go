@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// PrepareGraph preprocesses a stored graph in the background, and streams
+// its progress until queries can use it. A graph is prepared once for
+// each mode: asking again follows the preparation already started.
+func (s *graphServiceServer) PrepareGraph(req *pb.PrepareRequest, stream pb.GraphService_PrepareGraphServer) error {
+
+	switch req.Mode {
+	case pb.PrepareMode_CH:
+	case pb.PrepareMode_LANDMARKS:
+		if req.Landmarks < 0 {
+			return invalidRequestError("invalid number of landmarks", "landmarks", "landmarks must not be negative")
+		}
+	default:
+		return invalidRequestError("invalid preparation mode", "mode", "mode must be CH or LANDMARKS")
+	}
+
+	// Hold off mutations, so that any change to the graph after it
+	// is read drops the preparation
+	s.wmu.Lock()
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		s.wmu.Unlock()
+		return err
+	}
+	p := s.prepared.start(req.Gid.Id, req.Mode, builder(req, g))
+	s.wmu.Unlock()
+
+	for {
+		done, total, ready, err, changed := p.state()
+		if err != nil {
+			if err == context.Canceled {
+				return status.Error(codes.Aborted, "graph changed while it was prepared")
+			}
+			return graphError(req.Gid.Id, err)
+		}
+		if err := stream.Send(&pb.PrepareProgress{
+			Mode:  req.Mode,
+			Done:  int32(done),
+			Total: int32(total),
+			Ready: ready,
+		}); err != nil {
+			return err
+		}
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
+
+// The number of landmarks of a LANDMARKS preparation, unless requested
+const defaultLandmarks = 16
+
+// buildFunc prepares a graph, reporting its progress
+type buildFunc func(ctx context.Context, progress func(done, total int)) (interface{}, error)
+
+// builder returns the function preparing [g] as [req] asks
+func builder(req *pb.PrepareRequest, g *graph.ItemGraph) buildFunc {
+	if req.Mode == pb.PrepareMode_LANDMARKS {
+		k := int(req.Landmarks)
+		if k == 0 {
+			k = defaultLandmarks
+		}
+		selection := graph.FarthestLandmarks
+		if req.Selection == pb.LandmarkSelection_RANDOM {
+			selection = graph.RandomLandmarks
+		}
+		// Seeded by the graph ID, so that a graph gets the
+		// same landmarks each time it is prepared
+		r := rand.New(rand.NewSource(int64(req.Gid.Id)))
+		return func(ctx context.Context, progress func(done, total int)) (interface{}, error) {
+			return g.Landmarks(ctx, k, selection, r, progress)
+		}
+	}
+	return func(ctx context.Context, progress func(done, total int)) (interface{}, error) {
+		return g.ContractionHierarchy(ctx, progress)
+	}
+}
+
+// prepKey identifies the preparation of a graph in one mode
+type prepKey struct {
+	gid  int32
+	mode pb.PrepareMode
+}
+
+// preparation is the preprocessing of one graph, running or done
+type preparation struct {
+	cancel context.CancelFunc
+
+	done, total int
+	result      interface{}
+	err         error
+
+	// Closed, and replaced, whenever the state changes
+	changed chan struct{}
+
+	mu sync.Mutex
+}
+
+// state returns the progress of the preparation, whether it is ready,
+// why it failed, and a channel closed when any of these changes
+func (p *preparation) state() (int, int, bool, error, <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.done, p.total, p.result != nil, p.err, p.changed
+}
+
+// update records the progress, result, or failure of the preparation
+func (p *preparation) update(f func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	f()
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// preparations holds the preprocessed graphs, and those being
+// prepared. A preparation is dropped when its graph changes
+type preparations struct {
+	entries map[prepKey]*preparation
+	mu      sync.Mutex
+}
+
+func newPreparations() *preparations {
+	return &preparations{entries: make(map[prepKey]*preparation)}
+}
+
+// start runs [build] in the background to prepare graph [gid] in
+// [mode], unless it is already being prepared or ready
+func (ps *preparations) start(gid int32, mode pb.PrepareMode, build buildFunc) *preparation {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	key := prepKey{gid, mode}
+	if p, ok := ps.entries[key]; ok {
+		return p
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &preparation{cancel: cancel, changed: make(chan struct{})}
+	ps.entries[key] = p
+
+	go func() {
+		// Only report every percent of the work
+		reported := -1
+		progress := func(done, total int) {
+			if pct := done * 100 / total; pct != reported {
+				reported = pct
+				p.update(func() { p.done, p.total = done, total })
+			}
+		}
+
+		result, err := build(ctx, progress)
+		if err != nil {
+			// Let a failed preparation be started again
+			ps.mu.Lock()
+			if ps.entries[key] == p {
+				delete(ps.entries, key)
+			}
+			ps.mu.Unlock()
+			p.update(func() { p.err = err })
+			return
+		}
+		p.update(func() { p.result = result })
+	}()
+	return p
+}
+
+// get returns what preparing graph [gid] in [mode] produced,
+// or nil if it is not ready
+func (ps *preparations) get(gid int32, mode pb.PrepareMode) interface{} {
+	ps.mu.Lock()
+	p, ok := ps.entries[prepKey{gid, mode}]
+	ps.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.result
+}
+
+// hierarchy returns the contraction hierarchy of graph [gid], or nil
+// if it is not ready
+func (ps *preparations) hierarchy(gid int32) *graph.ContractionHierarchy {
+	h, _ := ps.get(gid, pb.PrepareMode_CH).(*graph.ContractionHierarchy)
+	return h
+}
+
+// landmarks returns the landmarks of graph [gid], or nil if they
+// are not ready
+func (ps *preparations) landmarks(gid int32) *graph.Landmarks {
+	lm, _ := ps.get(gid, pb.PrepareMode_LANDMARKS).(*graph.Landmarks)
+	return lm
+}
+
+// invalidate drops the preparations of graph [gid], stopping
+// those still running
+func (ps *preparations) invalidate(gid int32) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	for key, p := range ps.entries {
+		if key.gid == gid {
+			p.cancel()
+			delete(ps.entries, key)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+const (
+	// The page size of ListGraphs when the request has none
+	defaultPageSize = 50
+
+	// The largest page size of ListGraphs
+	maxPageSize = 1000
+)
+
+// GetGraph returns the graph stored with ID=[id]. Every edge is listed
+// in weighted_edges, so posting the reply creates an identical graph.
+func (s *graphServiceServer) GetGraph(ctx context.Context, id *pb.GraphID) (*pb.Graph, error) {
+
+	g, err := s.getGraph(id, "id")
+	if err != nil {
+		return nil, err
+	}
+	return graphMessage(g), nil
+}
+
+// graphMessage describes [g] with every edge listed in weighted_edges
+func graphMessage(g *graph.ItemGraph) *pb.Graph {
+	res := &pb.Graph{Directed: g.Directed()}
+	for _, n := range g.Nodes() {
+		res.Vertices = append(res.Vertices, int32(n.Value()))
+		if p, ok := n.Position(); ok {
+			if res.Positions == nil {
+				res.Positions = make(map[int32]*pb.Point)
+			}
+			res.Positions[int32(n.Value())] = &pb.Point{X: p.X, Y: p.Y}
+		}
+	}
+	edges, capacities := g.EdgesWithCapacity()
+	for i, e := range edges {
+		res.WeightedEdges = append(res.WeightedEdges, &pb.Edge{
+			V1:       &pb.Vertex{Id: int32(e.From)},
+			V2:       &pb.Vertex{Id: int32(e.To)},
+			Weight:   int32(e.Weight),
+			Capacity: int32(capacities[i]),
+		})
+	}
+	return res
+}
+
+// ListGraphs returns a page of the stored graphs, in order of ID, with
+// their sizes and creation times. The page token is the ID to resume at.
+func (s *graphServiceServer) ListGraphs(ctx context.Context, req *pb.ListGraphsRequest) (*pb.ListGraphsReply, error) {
+
+	size := int(req.PageSize)
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	start := int64(0)
+	if req.PageToken != "" {
+		var err error
+		start, err = strconv.ParseInt(req.PageToken, 10, 32)
+		if err != nil {
+			return nil, invalidRequestError("invalid page token",
+				"page_token", "use the next_page_token of a previous reply")
+		}
+	}
+
+	// Ask for one more graph than fits, to find where the next page starts
+	page, err := s.store.List(int32(start), size+1)
+	if err != nil {
+		return nil, storageError(err)
+	}
+
+	res := new(pb.ListGraphsReply)
+	if len(page) > size {
+		res.NextPageToken = strconv.Itoa(int(page[size].id))
+		page = page[:size]
+	}
+	for _, sg := range page {
+		res.Graphs = append(res.Graphs, &pb.GraphInfo{
+			Gid:         &pb.GraphID{Id: sg.id},
+			VertexCount: int32(sg.g.NodeCount()),
+			EdgeCount:   int32(sg.g.EdgeCount()),
+			CreatedAt:   timestamppb.New(sg.created),
+		})
+	}
+	return res, nil
+}
+
+// GetCacheStats returns the counters of the shortest path cache
+func (s *graphServiceServer) GetCacheStats(ctx context.Context, req *pb.CacheStatsRequest) (*pb.CacheStats, error) {
+	st := s.paths.stats()
+	return &pb.CacheStats{
+		Hits:      st.hits,
+		Misses:    st.misses,
+		Evictions: st.evictions,
+		Size:      int32(st.size),
+		Capacity:  int32(st.capacity),
+	}, nil
+}
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"net"
 	"sync"
+	"time"
 
 	graph "github.com/yc2454/Graph-Service/graph"
 	"google.golang.org/grpc"
@@ -16,33 +19,84 @@ import (
 )
 
 var (
-	port = flag.Int("port", 8080, "The server port")
+	port          = flag.Int("port", 8080, "The server port")
+	dataDir       = flag.String("data-dir", "", "The directory to keep the graphs in. If empty, graphs are only kept in memory")
+	snapshotEvery = flag.Int("snapshot-every", 1000, "The number of changes to the graphs between snapshots")
+	pathCacheSize = flag.Int("path-cache-size", defaultPathCacheSize, "The number of sources whose shortest paths are cached")
 )
 
 type graphServiceServer struct {
 	pb.UnimplementedGraphServiceServer
 
-	// A mapping from graph ID to stored graphs
-	graphs map[int32]*graph.ItemGraph
+	// Where the graphs are kept
+	store GraphStore
 
-	// The next ID to return for newly posted graph.
-	// Monotonically increase from 1
-	curID int32
+	// The shortest path trees of recently queried sources
+	paths *pathCache
 
-	mu sync.Mutex // protects routeNotes
+	// The graphs preprocessed by PrepareGraph
+	prepared *preparations
+
+	// The connected components of the graphs queried
+	labels *componentCache
+
+	// The scales of the A* heuristics of the graphs queried
+	scales *scaleCache
+
+	// Serializes the deletions of graphs and the mutations of their
+	// vertices and edges, so that each mutation is checked against
+	// the graph it is applied to
+	wmu sync.Mutex
 }
 
 // PostGraph receives a graph from the client, stores it in the server with an
 // ID, and return the graph ID if the graph is valid.
 func (s *graphServiceServer) PostGraph(ctx context.Context, g *pb.Graph) (*pb.GraphID, error) {
 
+	newGraph, err := buildGraph(g)
+	if err != nil {
+		return nil, err
+	}
+	return s.storeGraph(newGraph)
+}
+
+// storeGraph stores [g] under a new ID, and returns the ID
+func (s *graphServiceServer) storeGraph(g *graph.ItemGraph) (*pb.GraphID, error) {
+	id, err := s.store.NextID()
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if err := s.store.Put(&storedGraph{id: id, g: g, created: time.Now()}); err != nil {
+		return nil, storageError(err)
+	}
+	return &pb.GraphID{Id: id}, nil
+}
+
+// buildGraph builds the graph described by [g], checking that
+// it is valid
+func buildGraph(g *pb.Graph) (*graph.ItemGraph, error) {
+
 	// Initialize the graph to store
 	newGraph := graph.NewGraph()
+	if g.GetDirected() {
+		newGraph = graph.NewDirectedGraph()
+	}
+
+	positions, err := nodePositions(g.GetPositions(), g.GetVertices())
+	if err != nil {
+		return nil, err
+	}
 
 	// Record the nodes in the graph to post
-	for _, v := range g.GetVertices() {
+	for i, v := range g.GetVertices() {
 		n := graph.NewNode(int(v))
-		newGraph.AddNode(n)
+		if p, ok := positions[int(v)]; ok {
+			n = graph.NewNodeAt(int(v), p)
+		}
+		if err := newGraph.AddNode(n); err != nil {
+			return nil, invalidRequestError("found duplicate vertices",
+				fmt.Sprintf("vertices[%d]", i), fmt.Sprintf("vertex %d is listed more than once", v))
+		}
 	}
 
 	edges := g.GetEdges()
@@ -50,14 +104,15 @@ func (s *graphServiceServer) PostGraph(ctx context.Context, g *pb.Graph) (*pb.Gr
 	// Connect the edges in the graph to post
 	for _, v := range g.GetVertices() {
 		if edges[v] != nil {
-			for _, u := range edges[v].Neighbors {
+			for i, u := range edges[v].Neighbors {
 
 				// First, retrieve the nodes from the graph
 				n1, err1 := newGraph.FindNode(int(v))
 				n2, err2 := newGraph.FindNode(int(u))
 
 				if err1 != nil || err2 != nil {
-					return nil, errors.New("found edge between non-existant nodes")
+					return nil, invalidRequestError("found edge between non-existant nodes",
+						fmt.Sprintf("edges[%d].neighbors[%d]", v, i), fmt.Sprintf("vertex %d is not in vertices", u))
 				} else {
 					// Connect the edge if both nodes have been recorded
 					newGraph.AddEdge(n1, n2)
@@ -67,75 +122,318 @@ func (s *graphServiceServer) PostGraph(ctx context.Context, g *pb.Graph) (*pb.Gr
 		}
 	}
 
-	s.mu.Lock()
-	s.graphs[s.curID] = newGraph
-	id := new(pb.GraphID)
-	id.Id = int32(s.curID)
+	// Connect the weighted edges in the graph to post
+	for i, e := range g.GetWeightedEdges() {
+		n1, err1 := newGraph.FindNode(int(e.GetV1().GetId()))
+		n2, err2 := newGraph.FindNode(int(e.GetV2().GetId()))
 
-	// Increase the ID
-	s.curID++
-	s.mu.Unlock()
+		if e.GetV1() == nil || e.GetV2() == nil || err1 != nil || err2 != nil {
+			return nil, invalidRequestError("found edge between non-existant nodes",
+				fmt.Sprintf("weighted_edges[%d]", i), "both ends of the edge must be in vertices")
+		}
+		if e.Weight < 0 && !g.GetDirected() {
+			return nil, negativeWeightError(fmt.Sprintf("weighted_edges[%d].weight", i))
+		}
+		if e.Capacity < 0 {
+			return nil, negativeCapacityError(fmt.Sprintf("weighted_edges[%d].capacity", i))
+		}
+		newGraph.AddEdgeWithCapacity(n1, n2, int(e.Weight), int(e.Capacity))
+	}
+	return newGraph, nil
+}
 
-	return id, nil
+// nodePositions converts the positions of a request to the graph
+// package, checking that they are finite and that each one is the
+// position of a vertex in [vertices]
+func nodePositions(positions map[int32]*pb.Point, vertices []int32) (map[int]graph.Point, error) {
+	if len(positions) == 0 {
+		return nil, nil
+	}
+	listed := make(map[int32]bool, len(vertices))
+	for _, v := range vertices {
+		listed[v] = true
+	}
+
+	res := make(map[int]graph.Point, len(positions))
+	for v, p := range positions {
+		field := fmt.Sprintf("positions[%d]", v)
+		if !listed[v] {
+			return nil, invalidRequestError("found position of non-existant node",
+				field, fmt.Sprintf("vertex %d is not in vertices", v))
+		}
+		x, y := p.GetX(), p.GetY()
+		if math.IsNaN(x) || math.IsInf(x, 0) || math.IsNaN(y) || math.IsInf(y, 0) {
+			return nil, invalidRequestError("found invalid position",
+				field, "coordinates must be finite numbers")
+		}
+		res[int(v)] = graph.Point{X: x, Y: y}
+	}
+	return res, nil
+}
+
+// getGraph returns the graph stored with ID=[id]. [field] names the
+// ID in the request, for the error reported when it is missing.
+func (s *graphServiceServer) getGraph(id *pb.GraphID, field string) (*graph.ItemGraph, error) {
+	if id == nil {
+		return nil, missingGraphIDError(field)
+	}
+
+	sg, err := s.store.Get(id.Id)
+	if errors.Is(err, errGraphNotFound) {
+		return nil, graphNotFoundError(id.Id)
+	}
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return sg.g, nil
 }
 
 // ShortestPath takes the path request from the client, which contains a graph ID
-// and the start and end point of the path. It returns the shortest path if such a
-// path exists.
+// and the start and end point of the path. It returns the path of minimum total
+// weight, along with its cost, if such a path exists.
 func (s *graphServiceServer) ShortestPath(ctx context.Context, req *pb.PathRequest) (*pb.Path, error) {
 
-	g := s.graphs[req.Gid.Id]
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		return nil, err
+	}
 
 	// Retrieve the nodes from the graph first
 	n1, err1 := g.FindNode(int(req.S))
+	if err1 != nil {
+		return nil, vertexNotFoundError(req.Gid.Id, req.S)
+	}
 	n2, err2 := g.FindNode(int(req.T))
+	if err2 != nil {
+		return nil, vertexNotFoundError(req.Gid.Id, req.T)
+	}
+
+	var p []int
+	var cost, settled int
+	switch {
+	case g.HasNegativeWeights() || req.Algorithm == pb.PathAlgorithm_DIJKSTRA:
+		// Only the Bellman-Ford algorithm handles negative weights
+		p, cost, settled, err = s.treePath(req.Gid.Id, g, n1, n2)
+	case req.Algorithm == pb.PathAlgorithm_BELLMAN_FORD:
+		var tree *graph.PathTree
+		if tree, err = g.BellmanFord(n1); err != nil {
+			return nil, graphError(req.Gid.Id, err)
+		}
+		p, cost, err = tree.PathTo(n2.Value())
+		settled = tree.Len()
+	case req.Algorithm == pb.PathAlgorithm_A_STAR:
+		p, cost, settled, err = g.AStar(n1, n2, s.heuristic(req.Gid.Id, g, req.Heuristic))
+	case req.Algorithm == pb.PathAlgorithm_ALT:
+		p, cost, settled, err = g.ALT(n1, n2, s.prepared.landmarks(req.Gid.Id))
+	case req.Algorithm == pb.PathAlgorithm_BIDIRECTIONAL:
+		p, cost, settled, err = g.BidirectionalDijkstra(n1, n2)
+	default:
+		p, cost, settled, err = s.autoPath(req.Gid.Id, g, n1, n2)
+	}
+	if errors.Is(err, graph.ErrNoPath) {
+		return nil, noPathError(req)
+	}
+	if err != nil {
+		return nil, graphError(req.Gid.Id, err)
+	}
 
-	// [res] is used to store the result to return
+	// Record the path and its cost in [res]
 	res := new(pb.Path)
+	for _, n := range p {
+		res.Path = append(res.Path, int32(n))
+	}
+	res.Cost = int64(cost)
+	res.Settled = int32(settled)
+	return res, nil
+}
 
-	if err1 == nil && err2 == nil {
-		// Compute the shortest path
-		p, _ := g.GetShortestPath(n1, n2)
+// autoPath finds the shortest path from [start] to [end] in [g], the
+// graph with ID=[gid], which has no negative weights, the fastest way
+// it can: from the cached tree of [start] if there is one, and otherwise
+// by a query of the contraction hierarchy of the graph if it is prepared,
+// or by a bidirectional search until then. It also returns the number
+// of vertices settled, which is 0 for a cached tree
+func (s *graphServiceServer) autoPath(gid int32, g *graph.ItemGraph, start, end *graph.Node) ([]int, int, int, error) {
+	if tree := s.paths.get(gid, int32(start.Value())); tree != nil {
+		p, cost, err := tree.PathTo(end.Value())
+		return p, cost, 0, err
+	}
+	if ch := s.prepared.hierarchy(gid); ch != nil {
+		return ch.ShortestPath(start.Value(), end.Value())
+	}
+	return g.BidirectionalDijkstra(start, end)
+}
 
-		// Record the path in [res]
-		for _, n := range p {
-			res.Path = append(res.Path, int32(n))
-		}
-		return res, nil
+// treePath finds the shortest path from [start] to [end] in [g], the
+// graph with ID=[gid], through the tree of shortest paths from [start],
+// from the cache if it is there. It also returns the number of vertices
+// settled, which is 0 for a cached tree
+func (s *graphServiceServer) treePath(gid int32, g *graph.ItemGraph, start, end *graph.Node) ([]int, int, int, error) {
+	if tree := s.paths.get(gid, int32(start.Value())); tree != nil {
+		p, cost, err := tree.PathTo(end.Value())
+		return p, cost, 0, err
+	}
+	tree, err := s.searchTree(gid, g, start)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	p, cost, err := tree.PathTo(end.Value())
+	return p, cost, tree.Len(), err
+}
+
+// heuristic returns the requested A* heuristic for [g], the graph with
+// ID=[gid], scaled so that it never overestimates the cost of a path.
+// The scale is cached until the graph changes
+func (s *graphServiceServer) heuristic(gid int32, g *graph.ItemGraph, h pb.PathHeuristic) graph.Heuristic {
+	distance := graph.EuclideanDistance
+	if h == pb.PathHeuristic_HAVERSINE {
+		distance = graph.HaversineDistance
+	}
+	scale, ok, gen := s.scales.get(gid, h)
+	if !ok {
+		scale = g.CostPerDistance(distance)
+		s.scales.put(gid, h, gen, scale)
+	}
+
+	if h == pb.PathHeuristic_HAVERSINE {
+		return graph.Haversine{Scale: scale}
+	}
+	return graph.Euclidean{Scale: scale}
+}
+
+// ShortestPathTree returns the shortest paths from the requested source
+// to every vertex reachable from it, as the distance and predecessor of
+// each vertex.
+func (s *graphServiceServer) ShortestPathTree(ctx context.Context, req *pb.TreeRequest) (*pb.PathTree, error) {
+
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		return nil, err
+	}
+	source, err := g.FindNode(int(req.Source))
+	if err != nil {
+		return nil, vertexNotFoundError(req.Gid.Id, req.Source)
+	}
+
+	tree, err := s.pathTree(req.Gid.Id, g, source)
+	if err != nil {
+		return nil, graphError(req.Gid.Id, err)
+	}
+
+	res := &pb.PathTree{Source: req.Source}
+	for _, e := range tree.Entries() {
+		res.Vertices = append(res.Vertices, &pb.TreeVertex{
+			Id:          int32(e.Value),
+			Distance:    int64(e.Distance),
+			Predecessor: int32(e.Parent),
+		})
+	}
+	return res, nil
+}
 
+// pathTree returns the tree of shortest paths from [source] in [g],
+// the graph with ID=[gid], from the cache if it is there. On a graph
+// with negative weights, the tree is found with the Bellman-Ford
+// algorithm, which fails if a negative cycle can be reached
+func (s *graphServiceServer) pathTree(gid int32, g *graph.ItemGraph, source *graph.Node) (*graph.PathTree, error) {
+	if tree := s.paths.get(gid, int32(source.Value())); tree != nil {
+		return tree, nil
+	}
+	return s.searchTree(gid, g, source)
+}
+
+// searchTree finds the tree of shortest paths from [source] in [g], like
+// pathTree, without looking in the cache first, and caches it
+func (s *graphServiceServer) searchTree(gid int32, g *graph.ItemGraph, source *graph.Node) (*graph.PathTree, error) {
+	var tree *graph.PathTree
+	gen := s.paths.generation()
+	if g.HasNegativeWeights() {
+		var err error
+		if tree, err = g.BellmanFord(source); err != nil {
+			return nil, err
+		}
 	} else {
-		// When we cannot retrieve the nodes, return error
-		return nil, errors.New("non-existant node")
+		tree = g.ShortestPathTree(source)
 	}
+	s.paths.put(gid, int32(source.Value()), gen, tree)
+	return tree, nil
 }
 
 // DeleteGraph deletes the graph with ID=[id] from the server and
 // returns a message to the client if such graph exists.
 func (s *graphServiceServer) DeleteGraph(ctx context.Context, id *pb.GraphID) (*pb.DeleteReply, error) {
 
-	g := s.graphs[id.Id]
+	if id == nil {
+		return nil, missingGraphIDError("id")
+	}
+
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+
+	err := s.store.Delete(id.Id)
+	if errors.Is(err, errGraphNotFound) {
+		return nil, graphNotFoundError(id.Id)
+	}
+	if err != nil {
+		return nil, storageError(err)
+	}
+	s.invalidate(id.Id)
+
 	reply := new(pb.DeleteReply)
+	reply.Result = "Successfully deleted the graph"
+	return reply, nil
+}
 
-	if g == nil {
-		return nil, errors.New("non-existant graph")
-	} else {
-		s.mu.Lock()
-		s.graphs[id.Id] = nil
-		s.mu.Unlock()
-		reply.Result = "Successfully deleted the graph"
-		return reply, nil
+// invalidate drops what was computed from graph [id], after
+// it changed or was deleted
+func (s *graphServiceServer) invalidate(id int32) {
+	s.paths.invalidate(id)
+	s.prepared.invalidate(id)
+	s.labels.invalidate(id)
+	s.scales.invalidate(id)
+}
+
+// serverOption configures the server built by newServer
+type serverOption func(*graphServiceServer)
+
+// withStore makes the server keep its graphs in [store].
+// By default they are only kept in memory
+func withStore(store GraphStore) serverOption {
+	return func(s *graphServiceServer) {
+		s.store = store
 	}
+}
 
+// withPathCache makes the server cache the shortest path trees of
+// up to [size] sources, or none if [size] is 0
+func withPathCache(size int) serverOption {
+	return func(s *graphServiceServer) {
+		s.paths = newPathCache(size)
+	}
 }
 
 // Constructor of the server
-func newServer() *graphServiceServer {
+func newServer(opts ...serverOption) *graphServiceServer {
 	s := new(graphServiceServer)
-	s.graphs = make(map[int32]*graph.ItemGraph)
-	s.curID = 1
+	s.store = newMemoryStore()
+	s.paths = newPathCache(defaultPathCacheSize)
+	s.prepared = newPreparations()
+	s.labels = newComponentCache()
+	s.scales = newScaleCache()
+	for _, opt := range opts {
+		opt(s)
+	}
 	return s
 }
 
+// close releases the store of the server, if it holds any resources
+func (s *graphServiceServer) close() error {
+	if c, ok := s.store.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 func main() {
 	flag.Parse()
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
@@ -143,8 +441,19 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	opts := []serverOption{withPathCache(*pathCacheSize)}
+	if *dataDir != "" {
+		store, err := openFileStore(*dataDir, *snapshotEvery)
+		if err != nil {
+			log.Fatalf("failed to recover the graphs: %v", err)
+		}
+		defer store.Close()
+		opts = append(opts, withStore(store))
+	}
+	srv := newServer(opts...)
+
 	s := grpc.NewServer()
-	pb.RegisterGraphServiceServer(s, newServer())
+	pb.RegisterGraphServiceServer(s, srv)
 
 	log.Printf("server listening at %v", lis.Addr())
 	if err := s.Serve(lis); err != nil {
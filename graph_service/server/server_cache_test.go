@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// Test that the least frequently used tree is evicted first,
+// the least recently used among equals
+func TestPathCache_Eviction(t *testing.T) {
+	tree := graph.NewGraph().ShortestPathTree(graph.NewNode(0))
+	c := newPathCache(3)
+
+	c.put(1, 1, 0, tree)
+	c.put(1, 2, 0, tree)
+	c.put(1, 3, 0, tree)
+	c.get(1, 1)
+	c.get(1, 1)
+	c.get(1, 3)
+
+	// 2 is used least
+	c.put(1, 4, 0, tree)
+	if c.get(1, 2) != nil {
+		t.Error("expected source 2 to be evicted")
+	}
+
+	// 4 is used least now, and 3 only as much as 4 after this
+	c.get(1, 4)
+	c.put(1, 5, 0, tree)
+	if c.get(1, 3) != nil {
+		t.Error("expected source 3 to be evicted")
+	}
+	for _, source := range []int32{1, 4, 5} {
+		if c.get(1, source) == nil {
+			t.Error("expected source", source, "to be cached")
+		}
+	}
+
+	st := c.stats()
+	if st.evictions != 2 || st.size != 3 || st.capacity != 3 {
+		t.Error("stats: expected 2 evictions of 3 entries, received", st)
+	}
+}
+
+// Test that the trees of a changed graph are dropped, including
+// those computed before the change
+func TestPathCache_Invalidate(t *testing.T) {
+	tree := graph.NewGraph().ShortestPathTree(graph.NewNode(0))
+	c := newPathCache(10)
+
+	c.put(1, 1, 0, tree)
+	c.put(1, 2, 0, tree)
+	c.put(2, 1, 0, tree)
+	gen := c.generation()
+
+	c.invalidate(1)
+	if c.get(1, 1) != nil || c.get(1, 2) != nil {
+		t.Error("expected the trees of graph 1 to be dropped")
+	}
+	if c.get(2, 1) == nil {
+		t.Error("expected the tree of graph 2 to be kept")
+	}
+
+	c.put(1, 3, gen, tree)
+	if c.get(1, 3) != nil {
+		t.Error("expected a tree computed before the change not to be cached")
+	}
+	c.put(1, 3, c.generation(), tree)
+	if c.get(1, 3) == nil {
+		t.Error("expected a tree computed after the change to be cached")
+	}
+
+	// Eviction still works once the lowest count was dropped
+	for source := int32(10); source < 30; source++ {
+		c.put(3, source, c.generation(), tree)
+	}
+	if st := c.stats(); st.size != 10 {
+		t.Error("size: expected", 10, "received", st.size)
+	}
+
+	// A cache of size 0 holds nothing
+	c = newPathCache(0)
+	c.put(1, 1, 0, tree)
+	if c.get(1, 1) != nil {
+		t.Error("expected nothing to be cached")
+	}
+}
+
+// Test that queries from the same source are answered from the cache,
+// and that mutations and deletions are seen by later queries
+func TestGraphServer_PathCache(t *testing.T) {
+	ctx := context.Background()
+	s := newServer()
+
+	gid, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2, 3, 4},
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 1},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 1},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 3}, Weight: 5},
+		}})
+	if err != nil {
+		t.Fatal("PostGraph:", err)
+	}
+
+	tests := []struct {
+		name   string
+		change func() error
+		t      int32
+		path   []int32
+		cost   int64
+		hits   uint64
+		misses uint64
+	}{
+		{"first query", nil, 3, []int32{1, 2, 3}, 2, 0, 1},
+		{"same query", nil, 3, []int32{1, 2, 3}, 2, 1, 1},
+		{"other target", nil, 2, []int32{1, 2}, 1, 2, 1},
+		{
+			"after removing an edge",
+			func() error {
+				_, err := s.RemoveEdges(ctx, &pb.EdgesRequest{Gid: gid, Edges: []*pb.Edge{
+					{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}},
+				}})
+				return err
+			},
+			3, []int32{1, 3}, 5, 2, 2,
+		},
+		{
+			"after adding a vertex and edge",
+			func() error {
+				if _, err := s.AddVertices(ctx, &pb.VerticesRequest{Gid: gid, Vertices: []int32{5}}); err != nil {
+					return err
+				}
+				_, err := s.AddEdges(ctx, &pb.EdgesRequest{Gid: gid, Edges: []*pb.Edge{
+					{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 5}, Weight: 1},
+				}})
+				return err
+			},
+			5, []int32{1, 2, 5}, 2, 2, 3,
+		},
+	}
+
+	for _, tt := range tests {
+		if tt.change != nil {
+			if err := tt.change(); err != nil {
+				t.Fatal(tt.name, ":", err)
+			}
+		}
+		res, err := s.ShortestPath(ctx, &pb.PathRequest{Gid: gid, S: 1, T: tt.t, Algorithm: pb.PathAlgorithm_DIJKSTRA})
+		if err != nil {
+			t.Fatal(tt.name, ":", err)
+		}
+		if !Equal(res.Path, tt.path) || res.Cost != tt.cost {
+			t.Error(tt.name, ": expected", tt.path, "with cost", tt.cost, "received", res.Path, "with cost", res.Cost)
+		}
+		st, _ := s.GetCacheStats(ctx, &pb.CacheStatsRequest{})
+		if st.Hits != tt.hits || st.Misses != tt.misses {
+			t.Error(tt.name, ": expected", tt.hits, "hits and", tt.misses, "misses, received", st.Hits, "and", st.Misses)
+		}
+	}
+
+	if _, err := s.DeleteGraph(ctx, gid); err != nil {
+		t.Fatal("DeleteGraph:", err)
+	}
+	if st, _ := s.GetCacheStats(ctx, &pb.CacheStatsRequest{}); st.Size != 0 {
+		t.Error("size after DeleteGraph: expected", 0, "received", st.Size)
+	}
+}
+
+// Test that the scale of the A* heuristic of a graph is found again
+// after the graph changes, so that the heuristic never overestimates
+func TestGraphServer_ScaleCache(t *testing.T) {
+	ctx := context.Background()
+	s := newServer()
+
+	// A straight road 1 - 2 - 3, and a long way round through 4
+	gid, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2, 3, 4},
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 10},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 10},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 4}, Weight: 60},
+			{V1: &pb.Vertex{Id: 4}, V2: &pb.Vertex{Id: 3}, Weight: 60},
+		},
+		Positions: map[int32]*pb.Point{
+			1: {X: 0, Y: 0}, 2: {X: 1, Y: 0}, 3: {X: 2, Y: 0}, 4: {X: 1, Y: 5},
+		}})
+	if err != nil {
+		t.Fatal("PostGraph:", err)
+	}
+
+	req := &pb.PathRequest{Gid: gid, S: 1, T: 3, Algorithm: pb.PathAlgorithm_A_STAR}
+	if res, err := s.ShortestPath(ctx, req); err != nil || res.Cost != 20 {
+		t.Fatal("before: expected cost", 20, "received", res, err)
+	}
+	if _, ok, _ := s.scales.get(gid.Id, pb.PathHeuristic_EUCLIDEAN); !ok {
+		t.Error("before: expected the scale to be cached")
+	}
+
+	// The way round becomes the cheapest, far below
+	// the cost per distance of the straight road
+	_, err = s.AddEdges(ctx, &pb.EdgesRequest{Gid: gid, Edges: []*pb.Edge{
+		{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 4}, Weight: 1},
+		{V1: &pb.Vertex{Id: 4}, V2: &pb.Vertex{Id: 3}, Weight: 1},
+	}})
+	if err != nil {
+		t.Fatal("AddEdges:", err)
+	}
+	if res, err := s.ShortestPath(ctx, req); err != nil || res.Cost != 2 {
+		t.Error("after: expected cost", 2, "received", res, err)
+	}
+}
@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"io"
 	"log"
 	"net"
 	"testing"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
@@ -14,11 +17,16 @@ import (
 )
 
 func dialer() func(context.Context, string) (net.Conn, error) {
+	return dialerFor(newServer())
+}
+
+// dialerFor serves [srv], so that a test can look inside it
+func dialerFor(srv *graphServiceServer) func(context.Context, string) (net.Conn, error) {
 	listener := bufconn.Listen(1024 * 1024)
 
 	server := grpc.NewServer()
 
-	pb.RegisterGraphServiceServer(server, newServer())
+	pb.RegisterGraphServiceServer(server, srv)
 
 	go func() {
 		if err := server.Serve(listener); err != nil {
@@ -167,3 +175,315 @@ func TestGraphServer_SingleClient(t *testing.T) {
 		})
 	}
 }
+
+// Stream the distance matrix of a graph, with each algorithm
+func TestGraphServer_AllPairsShortestPaths(t *testing.T) {
+
+	ctx := context.Background()
+
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialer()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGraphServiceClient(conn)
+
+	// A directed path 3 -> 1 -> 2 with a shortcut, and an isolated vertex 4
+	id, err := client.PostGraph(ctx, &pb.Graph{Vertices: []int32{3, 1, 2, 4},
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 1}, Weight: 1},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 2},
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 2}, Weight: 5},
+		},
+		Directed: true})
+	if err != nil {
+		t.Fatal("cannot post graph", err)
+	}
+
+	// Rows and columns in order of ID, with -1 where unreachable
+	want := [][]int64{
+		{0, 2, -1, -1},
+		{-1, 0, -1, -1},
+		{1, 3, 0, -1},
+		{-1, -1, -1, 0},
+	}
+
+	algorithms := []pb.AllPairsAlgorithm{
+		pb.AllPairsAlgorithm_ALL_PAIRS_AUTO,
+		pb.AllPairsAlgorithm_FLOYD_WARSHALL,
+		pb.AllPairsAlgorithm_JOHNSON,
+	}
+	for _, algorithm := range algorithms {
+		t.Run(algorithm.String(), func(t *testing.T) {
+
+			stream, err := client.AllPairsShortestPaths(ctx, &pb.AllPairsRequest{Gid: id, Algorithm: algorithm})
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+
+			var rows []*pb.DistanceRow
+			for {
+				row, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal("unexpected error", err)
+				}
+				rows = append(rows, row)
+			}
+
+			if len(rows) != len(want) {
+				t.Fatal("rows: expected", len(want), "received", len(rows))
+			}
+			if !Equal(rows[0].Vertices, []int32{1, 2, 3, 4}) {
+				t.Error("columns: expected [1 2 3 4] received", rows[0].Vertices)
+			}
+			for i, row := range rows {
+				if row.Source != int32(i+1) {
+					t.Error("row", i, ": expected source", i+1, "received", row.Source)
+				}
+				for j, d := range want[i] {
+					if row.Reachable[j] != (d >= 0) || (d >= 0 && row.Distances[j] != d) {
+						t.Error("from", row.Source, "to", j+1, ": expected", d, "received", row.Distances[j], row.Reachable[j])
+					}
+				}
+			}
+		})
+	}
+
+	// A missing graph fails the stream
+	stream, err := client.AllPairsShortestPaths(ctx, &pb.AllPairsRequest{Gid: &pb.GraphID{Id: 100}})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if er, _ := status.FromError(err); er.Message() != "non-existant graph" {
+		t.Error("error message: expected", "non-existant graph", "received", err)
+	}
+
+	// Floyd-Warshall is refused on a graph too large for its matrix,
+	// while Johnson's algorithm streams its rows as it finds them
+	large := &pb.Graph{}
+	for v := int32(0); v <= maxFloydWarshallVertices; v++ {
+		large.Vertices = append(large.Vertices, v)
+	}
+	lid, err := client.PostGraph(ctx, large)
+	if err != nil {
+		t.Fatal("cannot post graph", err)
+	}
+	stream, err = client.AllPairsShortestPaths(ctx, &pb.AllPairsRequest{Gid: lid, Algorithm: pb.AllPairsAlgorithm_FLOYD_WARSHALL})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	er, _ := status.FromError(err)
+	var info *errdetails.ResourceInfo
+	for _, d := range er.Details() {
+		if ri, ok := d.(*errdetails.ResourceInfo); ok {
+			info = ri
+		}
+	}
+	if er.Code() != codes.FailedPrecondition || info == nil || info.ResourceName != graphName(lid.Id) {
+		t.Error("Floyd-Warshall limit: expected", codes.FailedPrecondition, "with the graph in details, received", err)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	stream, err = client.AllPairsShortestPaths(cctx, &pb.AllPairsRequest{Gid: lid})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if row, err := stream.Recv(); err != nil || len(row.Distances) != maxFloydWarshallVertices+1 {
+		t.Fatal("first row: expected", maxFloydWarshallVertices+1, "distances, received", row, err)
+	}
+	cancel()
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Canceled {
+		t.Error("canceled: expected", codes.Canceled, "received", err)
+	}
+}
+
+func TestGraphServer_PrepareGraph(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialerFor(s)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGraphServiceClient(conn)
+
+	// A 10 by 10 grid, with vertex 10*i+j at row i and column j
+	g := &pb.Graph{}
+	for v := int32(0); v < 100; v++ {
+		g.Vertices = append(g.Vertices, v)
+		if v%10 < 9 {
+			g.WeightedEdges = append(g.WeightedEdges,
+				&pb.Edge{V1: &pb.Vertex{Id: v}, V2: &pb.Vertex{Id: v + 1}, Weight: 1 + v*7%10})
+		}
+		if v < 90 {
+			g.WeightedEdges = append(g.WeightedEdges,
+				&pb.Edge{V1: &pb.Vertex{Id: v}, V2: &pb.Vertex{Id: v + 10}, Weight: 1 + v*3%10})
+		}
+	}
+	id, err := client.PostGraph(ctx, g)
+	if err != nil {
+		t.Fatal("cannot post graph", err)
+	}
+
+	stream, err := client.PrepareGraph(ctx, &pb.PrepareRequest{Gid: id, Mode: pb.PrepareMode_CH})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	var last *pb.PrepareProgress
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		if last != nil && progress.Done < last.Done {
+			t.Error("progress: went back from", last.Done, "to", progress.Done)
+		}
+		last = progress
+	}
+	if last == nil || !last.Ready || last.Done != 100 || last.Total != 100 {
+		t.Fatal("last progress: expected 100 of 100 and ready, received", last)
+	}
+	if s.prepared.hierarchy(id.Id) == nil {
+		t.Fatal("hierarchy: not ready after the stream ended")
+	}
+
+	// Queries of the hierarchy agree with bidirectional Dijkstra
+	compare := func(name string) {
+		for _, pair := range [][2]int32{{0, 99}, {9, 90}, {45, 54}, {3, 3}, {72, 17}} {
+			want, err1 := client.ShortestPath(ctx, &pb.PathRequest{Gid: id, S: pair[0], T: pair[1],
+				Algorithm: pb.PathAlgorithm_BIDIRECTIONAL})
+			got, err2 := client.ShortestPath(ctx, &pb.PathRequest{Gid: id, S: pair[0], T: pair[1]})
+			if err1 != nil || err2 != nil || got.Cost != want.Cost {
+				t.Error(name, ": path from", pair[0], "to", pair[1], ": expected", want, err1, "received", got, err2)
+			}
+		}
+	}
+	compare("prepared")
+
+	// A change drops the hierarchy
+	if _, err := client.RemoveEdges(ctx, &pb.EdgesRequest{Gid: id, Edges: []*pb.Edge{
+		{V1: &pb.Vertex{Id: 0}, V2: &pb.Vertex{Id: 1}}}}); err != nil {
+		t.Fatal("cannot remove edge", err)
+	}
+	if s.prepared.hierarchy(id.Id) != nil {
+		t.Error("hierarchy: still there after a change")
+	}
+	compare("changed")
+
+	// Only graphs without negative weights can be prepared
+	neg, _ := client.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2}, Directed: true,
+		WeightedEdges: []*pb.Edge{{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: -1}}})
+	stream, err = client.PrepareGraph(ctx, &pb.PrepareRequest{Gid: neg, Mode: pb.PrepareMode_CH})
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if er, _ := status.FromError(err); er.Message() != "graph has negative weights" {
+		t.Error("error message: expected", "graph has negative weights", "received", err)
+	}
+
+	stream, err = client.PrepareGraph(ctx, &pb.PrepareRequest{Gid: id})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if er, _ := status.FromError(err); er.Message() != "invalid preparation mode" {
+		t.Error("error message: expected", "invalid preparation mode", "received", err)
+	}
+}
+
+func TestGraphServer_Landmarks(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	conn, err := grpc.DialContext(ctx, "", grpc.WithInsecure(), grpc.WithContextDialer(dialerFor(s)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := pb.NewGraphServiceClient(conn)
+
+	// A 15 by 15 grid, with vertex 15*i+j at row i and column j
+	g := &pb.Graph{}
+	for v := int32(0); v < 225; v++ {
+		g.Vertices = append(g.Vertices, v)
+		if v%15 < 14 {
+			g.WeightedEdges = append(g.WeightedEdges,
+				&pb.Edge{V1: &pb.Vertex{Id: v}, V2: &pb.Vertex{Id: v + 1}, Weight: 10 + v*7%10})
+		}
+		if v < 210 {
+			g.WeightedEdges = append(g.WeightedEdges,
+				&pb.Edge{V1: &pb.Vertex{Id: v}, V2: &pb.Vertex{Id: v + 15}, Weight: 10 + v*3%10})
+		}
+	}
+	id, err := client.PostGraph(ctx, g)
+	if err != nil {
+		t.Fatal("cannot post graph", err)
+	}
+
+	// The total number of vertices settled by ALT over a few queries,
+	// checking that the paths are as cheap as bidirectional Dijkstra's
+	settled := func(name string) int32 {
+		total := int32(0)
+		for _, pair := range [][2]int32{{0, 224}, {14, 210}, {100, 124}, {7, 217}} {
+			want, err1 := client.ShortestPath(ctx, &pb.PathRequest{Gid: id, S: pair[0], T: pair[1],
+				Algorithm: pb.PathAlgorithm_BIDIRECTIONAL})
+			got, err2 := client.ShortestPath(ctx, &pb.PathRequest{Gid: id, S: pair[0], T: pair[1],
+				Algorithm: pb.PathAlgorithm_ALT})
+			if err1 != nil || err2 != nil || got.Cost != want.Cost || got.Settled == 0 {
+				t.Error(name, ": path from", pair[0], "to", pair[1], ": expected", want, err1, "received", got, err2)
+				continue
+			}
+			total += got.Settled
+		}
+		return total
+	}
+	before := settled("not prepared")
+
+	stream, err := client.PrepareGraph(ctx, &pb.PrepareRequest{Gid: id, Mode: pb.PrepareMode_LANDMARKS, Landmarks: 4})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	var last *pb.PrepareProgress
+	for {
+		progress, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		last = progress
+	}
+	if last == nil || !last.Ready || last.Done != 4 || last.Total != 4 {
+		t.Fatal("last progress: expected 4 of 4 and ready, received", last)
+	}
+	if lm := s.prepared.landmarks(id.Id); lm == nil || len(lm.Values()) != 4 {
+		t.Fatal("landmarks: expected 4, received", lm)
+	}
+
+	if after := settled("prepared"); after >= before {
+		t.Error("settled: expected fewer than", before, "vertices with landmarks, received", after)
+	}
+
+	stream, err = client.PrepareGraph(ctx, &pb.PrepareRequest{Gid: id, Mode: pb.PrepareMode_LANDMARKS, Landmarks: -1})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if er, _ := status.FromError(err); er.Message() != "invalid number of landmarks" {
+		t.Error("error message: expected", "invalid number of landmarks", "received", err)
+	}
+}
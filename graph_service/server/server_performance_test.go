@@ -164,3 +164,61 @@ func BenchmarkGraphServer_DeleteGraphPerf(b *testing.B) {
 	}
 
 }
+
+// Performance test for posting a graph with 100,000 vertices
+// and 200,000 edges
+func BenchmarkGraphServer_PostLargeGraphPerf(b *testing.B) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	n := int32(100000)
+	g := &pb.Graph{Edges: make(map[int32]*pb.Neighbors)}
+	for v := int32(0); v < n; v++ {
+		g.Vertices = append(g.Vertices, v)
+		g.Edges[v] = &pb.Neighbors{Neighbors: []int32{(v + 1) % n, (v * 7) % n}}
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.PostGraph(ctx, g); err != nil {
+			b.Error("cannot post graph", err)
+		}
+	}
+}
+
+// Performance test for A* queries through the server, on a 300 by 300
+// grid, including the lookup of the scale of the heuristic
+func BenchmarkGraphServer_AStarPerf(b *testing.B) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	const n = 300
+	g := &pb.Graph{Positions: make(map[int32]*pb.Point)}
+	for i := int32(0); i < n*n; i++ {
+		g.Vertices = append(g.Vertices, i)
+		g.Positions[i] = &pb.Point{X: float64(i % n), Y: float64(i / n)}
+		if i%n < n-1 {
+			g.WeightedEdges = append(g.WeightedEdges,
+				&pb.Edge{V1: &pb.Vertex{Id: i}, V2: &pb.Vertex{Id: i + 1}, Weight: 10 + i%7})
+		}
+		if i+n < n*n {
+			g.WeightedEdges = append(g.WeightedEdges,
+				&pb.Edge{V1: &pb.Vertex{Id: i}, V2: &pb.Vertex{Id: i + n}, Weight: 10 + i%5})
+		}
+	}
+	id, err := s.PostGraph(ctx, g)
+	if err != nil {
+		b.Fatal("cannot post graph", err)
+	}
+
+	// A short query in the middle of the grid
+	req := &pb.PathRequest{Gid: id, S: n*n/2 + n/2, T: n*n/2 + n/2 + 40, Algorithm: pb.PathAlgorithm_A_STAR}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.ShortestPath(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
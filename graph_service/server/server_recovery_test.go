@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// recoveryOps are the changes made to a durable server by the
+// recovery tests, one log record each
+var recoveryOps = []func(ctx context.Context, s *graphServiceServer) error{
+	func(ctx context.Context, s *graphServiceServer) error {
+		_, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2, 3},
+			WeightedEdges: []*pb.Edge{
+				{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 4},
+				{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 1, Capacity: 8},
+			}})
+		return err
+	},
+	func(ctx context.Context, s *graphServiceServer) error {
+		_, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2}, Directed: true,
+			Edges: map[int32]*pb.Neighbors{1: {Neighbors: []int32{2}}}})
+		return err
+	},
+	func(ctx context.Context, s *graphServiceServer) error {
+		_, err := s.AddVertices(ctx, &pb.VerticesRequest{Gid: &pb.GraphID{Id: 1}, Vertices: []int32{4, 5}})
+		return err
+	},
+	func(ctx context.Context, s *graphServiceServer) error {
+		_, err := s.AddEdges(ctx, &pb.EdgesRequest{Gid: &pb.GraphID{Id: 1}, Edges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 4}, Weight: 2, Capacity: 5},
+			{V1: &pb.Vertex{Id: 5}, V2: &pb.Vertex{Id: 1}, Weight: 7},
+		}})
+		return err
+	},
+	func(ctx context.Context, s *graphServiceServer) error {
+		_, err := s.RemoveEdges(ctx, &pb.EdgesRequest{Gid: &pb.GraphID{Id: 1}, Edges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}},
+		}})
+		return err
+	},
+	func(ctx context.Context, s *graphServiceServer) error {
+		_, err := s.DeleteGraph(ctx, &pb.GraphID{Id: 2})
+		return err
+	},
+	func(ctx context.Context, s *graphServiceServer) error {
+		_, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{7}})
+		return err
+	},
+	func(ctx context.Context, s *graphServiceServer) error {
+		_, err := s.RemoveVertices(ctx, &pb.VerticesRequest{Gid: &pb.GraphID{Id: 1}, Vertices: []int32{2}})
+		return err
+	},
+	// Deleting the graph with the highest ID must not let it be reused
+	func(ctx context.Context, s *graphServiceServer) error {
+		_, err := s.DeleteGraph(ctx, &pb.GraphID{Id: 3})
+		return err
+	},
+}
+
+// serverState describes the graphs held by [s] and the ID of the next
+// graph it will store
+func serverState(t *testing.T, s *graphServiceServer) *pb.Snapshot {
+	ctx := context.Background()
+	list, err := s.ListGraphs(ctx, &pb.ListGraphsRequest{PageSize: maxPageSize})
+	if err != nil {
+		t.Fatal("ListGraphs:", err)
+	}
+
+	mem := s.store.(*fileStore).mem
+	mem.mu.Lock()
+	state := &pb.Snapshot{NextId: mem.nextID}
+	mem.mu.Unlock()
+	for _, info := range list.Graphs {
+		g, err := s.GetGraph(ctx, info.Gid)
+		if err != nil {
+			t.Fatal("GetGraph:", err)
+		}
+		state.Graphs = append(state.Graphs, &pb.StoredGraph{Gid: info.Gid, Graph: g, CreatedAt: info.CreatedAt})
+	}
+	return state
+}
+
+// openServer starts a server with the fileStore kept in [dir]
+func openServer(t *testing.T, dir string, snapshotEvery int) *graphServiceServer {
+	store, err := openFileStore(dir, snapshotEvery)
+	if err != nil {
+		t.Fatal("openFileStore:", err)
+	}
+	return newServer(withStore(store))
+}
+
+// Test that a server recovers the state as of the last complete record,
+// wherever its log was cut by a crash
+func TestFileStore_TornLog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := openServer(t, dir, 0)
+
+	// The state of the server, and the size of its log,
+	// after each change
+	states := []*pb.Snapshot{serverState(t, s)}
+	sizes := []int64{0}
+	for i, op := range recoveryOps {
+		if err := op(ctx, s); err != nil {
+			t.Fatal("change", i, "failed:", err)
+		}
+		states = append(states, serverState(t, s))
+		sizes = append(sizes, s.store.(*fileStore).log.size)
+	}
+	s.close()
+
+	wal, err := os.ReadFile(filepath.Join(dir, walFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(wal)) != sizes[len(sizes)-1] {
+		t.Fatal("log size: expected", sizes[len(sizes)-1], "received", len(wal))
+	}
+
+	for cut := 0; cut <= len(wal); cut++ {
+		crashed := t.TempDir()
+		if err := os.WriteFile(filepath.Join(crashed, walFile), wal[:cut], 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		// The changes whose records were written in full
+		done := 0
+		for done+1 < len(sizes) && sizes[done+1] <= int64(cut) {
+			done++
+		}
+
+		r := openServer(t, crashed, 0)
+		if got := serverState(t, r); !proto.Equal(got, states[done]) {
+			t.Error("cut at", cut, ": expected", states[done], "received", got)
+		}
+
+		// The torn record is dropped, so new changes are recovered
+		id, err := r.PostGraph(ctx, &pb.Graph{Vertices: []int32{9}})
+		if err != nil {
+			t.Fatal("cut at", cut, ": PostGraph:", err)
+		}
+		if id.Id != states[done].NextId {
+			t.Error("cut at", cut, ": ID expected", states[done].NextId, "received", id.Id)
+		}
+		want := serverState(t, r)
+		r.close()
+
+		r = openServer(t, crashed, 0)
+		if got := serverState(t, r); !proto.Equal(got, want) {
+			t.Error("cut at", cut, ": after reopening, expected", want, "received", got)
+		}
+		r.close()
+	}
+}
+
+// Test that a server recovers from its snapshots and the records
+// written after them
+func TestFileStore_Snapshot(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := openServer(t, dir, 3)
+
+	for i, op := range recoveryOps {
+		if err := op(ctx, s); err != nil {
+			t.Fatal("change", i, "failed:", err)
+		}
+	}
+	want := serverState(t, s)
+	s.close()
+
+	snap, err := readSnapshot(filepath.Join(dir, snapshotFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Seq != 9 {
+		t.Error("snapshot seq: expected", 9, "received", snap.Seq)
+	}
+	recs, _, err := readLog(filepath.Join(dir, walFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 0 {
+		t.Error("log records after the snapshot: expected", 0, "received", len(recs))
+	}
+
+	s = openServer(t, dir, 3)
+	if got := serverState(t, s); !proto.Equal(got, want) {
+		t.Error("expected", want, "received", got)
+	}
+
+	// A change after recovery follows the snapshot in the log
+	if _, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1}}); err != nil {
+		t.Fatal("PostGraph:", err)
+	}
+	want = serverState(t, s)
+	s.close()
+
+	s = openServer(t, dir, 3)
+	if got := serverState(t, s); !proto.Equal(got, want) {
+		t.Error("expected", want, "received", got)
+	}
+	s.close()
+}
+
+// Test a crash after a snapshot is renamed into place, but before the
+// log is reset, with a partly written snapshot left behind
+func TestFileStore_CrashDuringSnapshot(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := openServer(t, dir, 0)
+
+	for i, op := range recoveryOps {
+		if err := op(ctx, s); err != nil {
+			t.Fatal("change", i, "failed:", err)
+		}
+	}
+	want := serverState(t, s)
+
+	wal, err := os.ReadFile(filepath.Join(dir, walFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := s.store.(*fileStore)
+	fs.mu.Lock()
+	err = fs.log.writeSnapshot(fs.snapshot())
+	fs.mu.Unlock()
+	if err != nil {
+		t.Fatal("writeSnapshot:", err)
+	}
+	s.close()
+
+	// Put back the log from before the reset
+	if err := os.WriteFile(filepath.Join(dir, walFile), wal, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, snapshotTmp), []byte("torn"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s = openServer(t, dir, 0)
+	if got := serverState(t, s); !proto.Equal(got, want) {
+		t.Error("expected", want, "received", got)
+	}
+
+	id, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1}})
+	if err != nil {
+		t.Fatal("PostGraph:", err)
+	}
+	if id.Id != want.NextId {
+		t.Error("ID expected", want.NextId, "received", id.Id)
+	}
+	want = serverState(t, s)
+	s.close()
+
+	s = openServer(t, dir, 0)
+	if got := serverState(t, s); !proto.Equal(got, want) {
+		t.Error("expected", want, "received", got)
+	}
+	s.close()
+}
+
+// Test that recovery fails rather than dropping a change it cannot apply
+func TestFileStore_BadRecord(t *testing.T) {
+	dir := t.TempDir()
+	f, err := os.Create(filepath.Join(dir, walFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &pb.LogRecord{Seq: 1, Op: &pb.LogRecord_AddVertices{
+		AddVertices: &pb.VerticesRequest{Gid: &pb.GraphID{Id: 1}, Vertices: []int32{1}},
+	}}
+	if _, err := writeFrame(f, rec); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if _, err := openFileStore(dir, 0); err == nil {
+		t.Error("expected recovery to fail on a mutation of a missing graph")
+	}
+}
+
+// Test that the next ID and creation times in a snapshot are kept,
+// even when the graph with the highest ID was deleted
+func TestFileStore_SnapshotNextID(t *testing.T) {
+	dir := t.TempDir()
+	created := timestamppb.New(time.Now().Add(-time.Hour))
+	snap := &pb.Snapshot{Seq: 0, NextId: 5, Graphs: []*pb.StoredGraph{
+		{Gid: &pb.GraphID{Id: 2}, Graph: &pb.Graph{Vertices: []int32{1}}, CreatedAt: created},
+	}}
+	if err := writeFileSync(filepath.Join(dir, snapshotFile), snap); err != nil {
+		t.Fatal(err)
+	}
+	s := openServer(t, dir, 0)
+	defer s.close()
+	got := serverState(t, s)
+	if got.NextId != 5 || len(got.Graphs) != 1 || !proto.Equal(got.Graphs[0].CreatedAt, created) {
+		t.Error("expected", snap, "received", got)
+	}
+}
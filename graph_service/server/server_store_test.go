@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// The GraphStore implementations under test, each opened empty
+var graphStores = []struct {
+	name string
+	open func(t *testing.T) GraphStore
+}{
+	{
+		"memory",
+		func(t *testing.T) GraphStore { return newMemoryStore() },
+	},
+	{
+		"file",
+		func(t *testing.T) GraphStore {
+			fs, err := openFileStore(t.TempDir(), 2)
+			if err != nil {
+				t.Fatal("openFileStore:", err)
+			}
+			t.Cleanup(func() { fs.Close() })
+			return fs
+		},
+	},
+}
+
+// Test that every GraphStore implementation behaves the same
+func TestGraphStore_Conformance(t *testing.T) {
+	for _, st := range graphStores {
+		t.Run(st.name, func(t *testing.T) {
+			t.Run("GetMissing", func(t *testing.T) { testStoreGetMissing(t, st.open(t)) })
+			t.Run("PutGet", func(t *testing.T) { testStorePutGet(t, st.open(t)) })
+			t.Run("Delete", func(t *testing.T) { testStoreDelete(t, st.open(t)) })
+			t.Run("List", func(t *testing.T) { testStoreList(t, st.open(t)) })
+			t.Run("NextID", func(t *testing.T) { testStoreNextID(t, st.open(t)) })
+			t.Run("Concurrent", func(t *testing.T) { testStoreConcurrent(t, st.open(t)) })
+		})
+	}
+}
+
+// newStoredGraph returns a graph to store with ID=[id] and the vertices [values]
+func newStoredGraph(id int32, values ...int) *storedGraph {
+	g := graph.NewGraph()
+	for _, v := range values {
+		g.AddNode(graph.NewNode(v))
+	}
+	return &storedGraph{id: id, g: g, created: time.Now()}
+}
+
+// putGraphs stores graphs with IDs from [store], failing the test on an error
+func putGraphs(t *testing.T, store GraphStore, n int) []int32 {
+	var ids []int32
+	for i := 0; i < n; i++ {
+		id, err := store.NextID()
+		if err != nil {
+			t.Fatal("NextID:", err)
+		}
+		if err := store.Put(newStoredGraph(id, i)); err != nil {
+			t.Fatal("Put:", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func testStoreGetMissing(t *testing.T, store GraphStore) {
+	if _, err := store.Get(1); !errors.Is(err, errGraphNotFound) {
+		t.Error("Get: expected", errGraphNotFound, "received", err)
+	}
+	if err := store.Delete(1); !errors.Is(err, errGraphNotFound) {
+		t.Error("Delete: expected", errGraphNotFound, "received", err)
+	}
+}
+
+func testStorePutGet(t *testing.T, store GraphStore) {
+	sg := newStoredGraph(3, 1, 2)
+	if err := store.Put(sg); err != nil {
+		t.Fatal("Put:", err)
+	}
+	got, err := store.Get(3)
+	if err != nil {
+		t.Fatal("Get:", err)
+	}
+	if got.id != 3 || got.g.NodeCount() != 2 || !got.created.Equal(sg.created) {
+		t.Error("Get: expected", sg, "received", got)
+	}
+
+	// Put replaces the graph stored under the same ID
+	if err := store.Put(newStoredGraph(3, 1, 2, 3)); err != nil {
+		t.Fatal("Put:", err)
+	}
+	got, err = store.Get(3)
+	if err != nil {
+		t.Fatal("Get:", err)
+	}
+	if got.g.NodeCount() != 3 {
+		t.Error("vertex count after replacing: expected", 3, "received", got.g.NodeCount())
+	}
+}
+
+func testStoreDelete(t *testing.T, store GraphStore) {
+	ids := putGraphs(t, store, 2)
+	if err := store.Delete(ids[0]); err != nil {
+		t.Fatal("Delete:", err)
+	}
+	if _, err := store.Get(ids[0]); !errors.Is(err, errGraphNotFound) {
+		t.Error("Get after Delete: expected", errGraphNotFound, "received", err)
+	}
+	if err := store.Delete(ids[0]); !errors.Is(err, errGraphNotFound) {
+		t.Error("second Delete: expected", errGraphNotFound, "received", err)
+	}
+	if _, err := store.Get(ids[1]); err != nil {
+		t.Error("Get of the other graph:", err)
+	}
+}
+
+func testStoreList(t *testing.T, store GraphStore) {
+	ids := putGraphs(t, store, 5)
+	if err := store.Delete(ids[2]); err != nil {
+		t.Fatal("Delete:", err)
+	}
+
+	tests := []struct {
+		start int32
+		limit int
+		res   []int32
+	}{
+		{0, 10, []int32{ids[0], ids[1], ids[3], ids[4]}},
+		{0, 2, []int32{ids[0], ids[1]}},
+		{ids[2], 10, []int32{ids[3], ids[4]}},
+		{ids[4] + 1, 10, nil},
+		{0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		page, err := store.List(tt.start, tt.limit)
+		if err != nil {
+			t.Fatal("List:", err)
+		}
+		var got []int32
+		for _, sg := range page {
+			got = append(got, sg.id)
+		}
+		if !Equal(got, tt.res) {
+			t.Error("List(", tt.start, tt.limit, "): expected", tt.res, "received", got)
+		}
+	}
+}
+
+func testStoreNextID(t *testing.T, store GraphStore) {
+	ids := putGraphs(t, store, 3)
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Error("NextID: expected increasing IDs, received", ids)
+		}
+	}
+
+	// Deleting the graph with the highest ID does not free it
+	if err := store.Delete(ids[2]); err != nil {
+		t.Fatal("Delete:", err)
+	}
+	id, err := store.NextID()
+	if err != nil {
+		t.Fatal("NextID:", err)
+	}
+	if id <= ids[2] {
+		t.Error("NextID after Delete: expected above", ids[2], "received", id)
+	}
+
+	// Nor is an ID a graph was put under
+	if err := store.Put(newStoredGraph(id+10, 1)); err != nil {
+		t.Fatal("Put:", err)
+	}
+	next, err := store.NextID()
+	if err != nil {
+		t.Fatal("NextID:", err)
+	}
+	if next <= id+10 {
+		t.Error("NextID after Put: expected above", id+10, "received", next)
+	}
+}
+
+func testStoreConcurrent(t *testing.T, store GraphStore) {
+	const workers, each = 8, 20
+
+	var mu sync.Mutex
+	seen := make(map[int32]bool)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				id, err := store.NextID()
+				if err != nil {
+					t.Error("NextID:", err)
+					return
+				}
+				if err := store.Put(newStoredGraph(id, i)); err != nil {
+					t.Error("Put:", err)
+					return
+				}
+				mu.Lock()
+				if seen[id] {
+					t.Error("NextID: returned", id, "twice")
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	page, err := store.List(0, 2*workers*each)
+	if err != nil {
+		t.Fatal("List:", err)
+	}
+	if len(page) != workers*each {
+		t.Error("List: expected", workers*each, "graphs, received", len(page))
+	}
+}
+
+// Test the server on top of each GraphStore
+func TestGraphServer_Stores(t *testing.T) {
+	ctx := context.Background()
+	for _, st := range graphStores {
+		t.Run(st.name, func(t *testing.T) {
+			s := newServer(withStore(st.open(t)))
+
+			gid, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2, 3},
+				Edges: map[int32]*pb.Neighbors{1: {Neighbors: []int32{2}}}})
+			if err != nil {
+				t.Fatal("PostGraph:", err)
+			}
+			if _, err := s.AddEdges(ctx, &pb.EdgesRequest{Gid: gid, Edges: []*pb.Edge{
+				{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 5},
+			}}); err != nil {
+				t.Fatal("AddEdges:", err)
+			}
+
+			path, err := s.ShortestPath(ctx, &pb.PathRequest{Gid: gid, S: 1, T: 3})
+			if err != nil {
+				t.Fatal("ShortestPath:", err)
+			}
+			if !Equal(path.Path, []int32{1, 2, 3}) || path.Cost != 6 {
+				t.Error("ShortestPath: expected [1 2 3] with cost 6, received", path.Path, "with cost", path.Cost)
+			}
+
+			if _, err := s.DeleteGraph(ctx, gid); err != nil {
+				t.Fatal("DeleteGraph:", err)
+			}
+			if _, err := s.GetGraph(ctx, gid); err == nil {
+				t.Error("GetGraph: expected an error after DeleteGraph")
+			}
+		})
+	}
+}
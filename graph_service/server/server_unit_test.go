@@ -2,9 +2,15 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"testing"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	pb "github.com/yc2454/Graph-Service/graph_service"
 )
@@ -59,99 +65,1166 @@ func TestGraphServer_PostGraph(t *testing.T) {
 			nil,
 			"found edge between non-existant nodes",
 		},
+		{
+			"invalid graph with duplicate vertices",
+			&pb.Graph{Vertices: []int32{1, 2, 2},
+				Edges: map[int32]*pb.Neighbors{
+					1: {Neighbors: []int32{2}},
+				}},
+			nil,
+			"found duplicate vertices",
+		},
+	}
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Check the output of the function
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			id, err := s.PostGraph(ctx, tt.graph)
+
+			if id != nil {
+				if id.Id != tt.res.Id {
+					t.Error("response: expected", tt.res.Id, "received", id.Id)
+				}
+			}
+
+			if err != nil {
+				if er, ok := status.FromError(err); ok {
+					if er.Message() != tt.errMsg {
+						t.Error("error message: expected", tt.errMsg, "received", er.Message())
+					}
+				}
+			}
+		})
+	}
+}
+
+// Test the ShortestPath function
+func TestGraphServer_ShortestPath(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// First, post a graph to the server
+	g := &pb.Graph{Vertices: []int32{1, 2, 3, 4, 5},
+		Edges: map[int32]*pb.Neighbors{
+			1: {Neighbors: []int32{2, 3, 4, 5}},
+			2: {Neighbors: []int32{1}},
+			3: {Neighbors: []int32{1}},
+			4: {Neighbors: []int32{1}},
+			5: {Neighbors: []int32{1}},
+		}}
+
+	id, err0 := s.PostGraph(ctx, g)
+	if err0 != nil {
+		t.Error("cannot post graph", err0)
+	}
+
+	// Query for 2 shortest paths
+	tests := []struct {
+		name   string
+		req    *pb.PathRequest
+		res    *pb.Path
+		errMsg string
+	}{
+		{
+			"base case",
+			&pb.PathRequest{S: 1, T: 2, Gid: id},
+			&pb.Path{Path: []int32{1, 2}},
+			"",
+		},
+		{
+			"longer path",
+			&pb.PathRequest{S: 3, T: 2, Gid: id},
+			&pb.Path{Path: []int32{3, 1, 2}},
+			"found edge between non-existant nodes",
+		},
+	}
+
+	// Check the output of the function
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			path, err := s.ShortestPath(ctx, tt.req)
+
+			if path != nil {
+				if !Equal(path.Path, tt.res.Path) {
+					t.Error("response: expected", tt.res.Path, "received", path.Path)
+				}
+			}
+
+			if err != nil {
+				if er, ok := status.FromError(err); ok {
+					if er.Message() != tt.errMsg {
+						t.Error("error message: expected", tt.errMsg, "received", er.Message())
+					}
+				}
+			}
+		})
+	}
+}
+
+// Test the ShortestPath function on a weighted graph
+func TestGraphServer_WeightedShortestPath(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Post a graph where the direct edge 1-4 is more
+	// expensive than the detour through 2 and 3
+	g := &pb.Graph{Vertices: []int32{1, 2, 3, 4, 5},
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 4}, Weight: 10},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 2},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 3},
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 4}, Weight: 1},
+			{V1: &pb.Vertex{Id: 4}, V2: &pb.Vertex{Id: 5}, Weight: 4},
+		}}
+
+	id, err0 := s.PostGraph(ctx, g)
+	if err0 != nil {
+		t.Error("cannot post graph", err0)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.PathRequest
+		res  *pb.Path
+	}{
+		{
+			"single edge",
+			&pb.PathRequest{S: 1, T: 2, Gid: id},
+			&pb.Path{Path: []int32{1, 2}, Cost: 2},
+		},
+		{
+			"detour cheaper than direct edge",
+			&pb.PathRequest{S: 1, T: 4, Gid: id},
+			&pb.Path{Path: []int32{1, 2, 3, 4}, Cost: 6},
+		},
+		{
+			"reverse direction",
+			&pb.PathRequest{S: 5, T: 2, Gid: id},
+			&pb.Path{Path: []int32{5, 4, 3, 2}, Cost: 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			path, err := s.ShortestPath(ctx, tt.req)
+
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			if !Equal(path.Path, tt.res.Path) {
+				t.Error("response: expected", tt.res.Path, "received", path.Path)
+			}
+			if path.Cost != tt.res.Cost {
+				t.Error("cost: expected", tt.res.Cost, "received", path.Cost)
+			}
+		})
+	}
+
+	// Negative weights are rejected
+	_, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2},
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: -1},
+		}})
+	if err == nil {
+		t.Error("posted a graph with a negative edge weight")
+	}
+}
+
+// Test the ShortestPath function on a directed graph
+func TestGraphServer_DirectedShortestPath(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Post a directed cycle 1 -> 2 -> 3 -> 1 with a
+	// one-way shortcut 1 -> 3
+	g := &pb.Graph{Vertices: []int32{1, 2, 3},
+		Edges: map[int32]*pb.Neighbors{
+			1: {Neighbors: []int32{2, 3}},
+			2: {Neighbors: []int32{3}},
+			3: {Neighbors: []int32{1}},
+		},
+		Directed: true}
+
+	id, err0 := s.PostGraph(ctx, g)
+	if err0 != nil {
+		t.Error("cannot post graph", err0)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.PathRequest
+		res  *pb.Path
+	}{
+		{
+			"along the edges",
+			&pb.PathRequest{S: 1, T: 3, Gid: id},
+			&pb.Path{Path: []int32{1, 3}, Cost: 1},
+		},
+		{
+			"around the cycle, not back along 2 -> 3",
+			&pb.PathRequest{S: 3, T: 2, Gid: id},
+			&pb.Path{Path: []int32{3, 1, 2}, Cost: 2},
+		},
+		{
+			"around the cycle, not back along the shortcut",
+			&pb.PathRequest{S: 2, T: 1, Gid: id},
+			&pb.Path{Path: []int32{2, 3, 1}, Cost: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			path, err := s.ShortestPath(ctx, tt.req)
+
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			if !Equal(path.Path, tt.res.Path) {
+				t.Error("response: expected", tt.res.Path, "received", path.Path)
+			}
+			if path.Cost != tt.res.Cost {
+				t.Error("cost: expected", tt.res.Cost, "received", path.Cost)
+			}
+		})
+	}
+}
+
+// Test the ShortestPathTree function
+func TestGraphServer_ShortestPathTree(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Post a directed graph where 5 cannot be reached from 1
+	g := &pb.Graph{Vertices: []int32{1, 2, 3, 4, 5},
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 3},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 3}, Weight: 1},
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 2}, Weight: 1},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 4}, Weight: 4},
+			{V1: &pb.Vertex{Id: 5}, V2: &pb.Vertex{Id: 1}, Weight: 1},
+		},
+		Directed: true}
+
+	id, err0 := s.PostGraph(ctx, g)
+	if err0 != nil {
+		t.Fatal("cannot post graph", err0)
+	}
+
+	tests := []struct {
+		name   string
+		req    *pb.TreeRequest
+		res    []*pb.TreeVertex
+		errMsg string
+	}{
+		{
+			"from 1",
+			&pb.TreeRequest{Gid: id, Source: 1},
+			[]*pb.TreeVertex{
+				{Id: 1, Distance: 0, Predecessor: 1},
+				{Id: 3, Distance: 1, Predecessor: 1},
+				{Id: 2, Distance: 2, Predecessor: 3},
+				{Id: 4, Distance: 6, Predecessor: 2},
+			},
+			"",
+		},
+		{
+			"from a sink",
+			&pb.TreeRequest{Gid: id, Source: 4},
+			[]*pb.TreeVertex{{Id: 4, Distance: 0, Predecessor: 4}},
+			"",
+		},
+		{
+			"non-existant source",
+			&pb.TreeRequest{Gid: id, Source: 6},
+			nil,
+			"non-existant node",
+		},
+		{
+			"non-existant graph",
+			&pb.TreeRequest{Gid: &pb.GraphID{Id: 100}, Source: 1},
+			nil,
+			"non-existant graph",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			tree, err := s.ShortestPathTree(ctx, tt.req)
+
+			if tt.errMsg != "" {
+				if er, _ := status.FromError(err); er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			if tree.Source != tt.req.Source {
+				t.Error("source: expected", tt.req.Source, "received", tree.Source)
+			}
+			if len(tree.Vertices) != len(tt.res) {
+				t.Fatal("response: expected", tt.res, "received", tree.Vertices)
+			}
+			for i, v := range tree.Vertices {
+				if !proto.Equal(v, tt.res[i]) {
+					t.Error("vertex", i, ": expected", tt.res[i], "received", v)
+				}
+			}
+		})
+	}
+}
+
+// Test the KShortestPaths function
+func TestGraphServer_KShortestPaths(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Post a square 1 - 2 - 4 - 3 - 1 with a diagonal 1 - 4
+	g := &pb.Graph{Vertices: []int32{1, 2, 3, 4},
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 1},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 4}, Weight: 1},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 3}, Weight: 2},
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 4}, Weight: 2},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 4}, Weight: 3},
+		}}
+
+	id, err0 := s.PostGraph(ctx, g)
+	if err0 != nil {
+		t.Fatal("cannot post graph", err0)
+	}
+
+	tests := []struct {
+		name   string
+		req    *pb.KPathsRequest
+		res    []*pb.Path
+		errMsg string
+	}{
+		{
+			"two paths",
+			&pb.KPathsRequest{Gid: id, S: 1, T: 4, K: 2},
+			[]*pb.Path{
+				{Path: []int32{1, 2, 4}, Cost: 2},
+				{Path: []int32{1, 4}, Cost: 3},
+			},
+			"",
+		},
+		{
+			"more paths than there are",
+			&pb.KPathsRequest{Gid: id, S: 1, T: 4, K: 10},
+			[]*pb.Path{
+				{Path: []int32{1, 2, 4}, Cost: 2},
+				{Path: []int32{1, 4}, Cost: 3},
+				{Path: []int32{1, 3, 4}, Cost: 4},
+			},
+			"",
+		},
+		{
+			"invalid k",
+			&pb.KPathsRequest{Gid: id, S: 1, T: 4, K: 0},
+			nil,
+			"invalid number of paths",
+		},
+		{
+			"non-existant target",
+			&pb.KPathsRequest{Gid: id, S: 1, T: 5, K: 2},
+			nil,
+			"non-existant node",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			paths, err := s.KShortestPaths(ctx, tt.req)
+
+			if tt.errMsg != "" {
+				if er, _ := status.FromError(err); er.Message() != tt.errMsg {
+					t.Error("error message: expected", tt.errMsg, "received", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			if len(paths.Paths) != len(tt.res) {
+				t.Fatal("response: expected", tt.res, "received", paths.Paths)
+			}
+			for i, p := range paths.Paths {
+				if !proto.Equal(p, tt.res[i]) {
+					t.Error("path", i, ": expected", tt.res[i], "received", p)
+				}
+			}
+		})
+	}
+}
+
+// Test the BatchShortestPath function
+func TestGraphServer_AStar(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Post a path 1 - 2 - 3 along the x axis, and a detour
+	// through 4 above it
+	g := &pb.Graph{Vertices: []int32{1, 2, 3, 4},
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 10},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 10},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 4}, Weight: 15},
+			{V1: &pb.Vertex{Id: 4}, V2: &pb.Vertex{Id: 3}, Weight: 15},
+		},
+		Positions: map[int32]*pb.Point{
+			1: {X: 0, Y: 0}, 2: {X: 1, Y: 0}, 3: {X: 2, Y: 0}, 4: {X: 1, Y: 1},
+		}}
+
+	id, err0 := s.PostGraph(ctx, g)
+	if err0 != nil {
+		t.Fatal("cannot post graph", err0)
+	}
+
+	// Positions are kept with the graph
+	stored, err1 := s.GetGraph(ctx, id)
+	if err1 != nil {
+		t.Fatal("cannot get graph", err1)
+	}
+	if !proto.Equal(stored.Positions[4], g.Positions[4]) || len(stored.Positions) != 4 {
+		t.Error("positions: expected", g.Positions, "received", stored.Positions)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.PathRequest
+		res  *pb.Path
+	}{
+		{
+			"dijkstra",
+			&pb.PathRequest{Gid: id, S: 1, T: 3, Algorithm: pb.PathAlgorithm_DIJKSTRA},
+			&pb.Path{Path: []int32{1, 2, 3}, Cost: 20, Settled: 4},
+		},
+		{
+			"bidirectional",
+			&pb.PathRequest{Gid: id, S: 1, T: 3, Algorithm: pb.PathAlgorithm_BIDIRECTIONAL},
+			&pb.Path{Path: []int32{1, 2, 3}, Cost: 20, Settled: 2},
+		},
+		{
+			"euclidean",
+			&pb.PathRequest{Gid: id, S: 1, T: 3, Algorithm: pb.PathAlgorithm_A_STAR},
+			&pb.Path{Path: []int32{1, 2, 3}, Cost: 20, Settled: 3},
+		},
+		{
+			"haversine",
+			&pb.PathRequest{Gid: id, S: 3, T: 1, Algorithm: pb.PathAlgorithm_A_STAR,
+				Heuristic: pb.PathHeuristic_HAVERSINE},
+			&pb.Path{Path: []int32{3, 2, 1}, Cost: 20, Settled: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := s.ShortestPath(ctx, tt.req)
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+			if !proto.Equal(path, tt.res) {
+				t.Error("response: expected", tt.res, "received", path)
+			}
+		})
+	}
+
+	// A vertex added without a position is still searched, and a
+	// cheap path through it is not overestimated
+	if _, err := s.AddVertices(ctx, &pb.VerticesRequest{Gid: id, Vertices: []int32{5}}); err != nil {
+		t.Fatal("cannot add vertex", err)
+	}
+	if _, err := s.AddEdges(ctx, &pb.EdgesRequest{Gid: id, Edges: []*pb.Edge{
+		{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 5}, Weight: 1},
+		{V1: &pb.Vertex{Id: 5}, V2: &pb.Vertex{Id: 3}, Weight: 1}}}); err != nil {
+		t.Fatal("cannot add edges", err)
+	}
+	for _, h := range []pb.PathHeuristic{pb.PathHeuristic_EUCLIDEAN, pb.PathHeuristic_HAVERSINE} {
+		path, err := s.ShortestPath(ctx, &pb.PathRequest{Gid: id, S: 1, T: 3,
+			Algorithm: pb.PathAlgorithm_A_STAR, Heuristic: h})
+		if err != nil || !Equal(path.Path, []int32{1, 5, 3}) || path.Cost != 2 {
+			t.Error(h, ": expected", []int32{1, 5, 3}, "with cost", 2, "received", path, err)
+		}
+	}
+
+	// Positions must belong to listed vertices
+	bad := &pb.Graph{Vertices: []int32{1}, Positions: map[int32]*pb.Point{2: {}}}
+	if _, err := s.PostGraph(ctx, bad); status.Convert(err).Message() != "found position of non-existant node" {
+		t.Error("error: expected", "found position of non-existant node", "received", err)
+	}
+}
+
+// Test that graphs with negative weights are searched with the
+// Bellman-Ford algorithm, and that negative cycles are reported
+func TestGraphServer_NegativeWeights(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// The rebate on 3 -> 2 makes the detour through 3 cheapest
+	g := &pb.Graph{Vertices: []int32{1, 2, 3, 4}, Directed: true,
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 1},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 3}, Weight: 5},
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 2}, Weight: -10},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 4}, Weight: 1},
+		}}
+
+	id, err0 := s.PostGraph(ctx, g)
+	if err0 != nil {
+		t.Fatal("cannot post graph", err0)
+	}
+
+	expected := &pb.Path{Path: []int32{1, 3, 2, 4}, Cost: -4}
+	for _, algo := range []pb.PathAlgorithm{pb.PathAlgorithm_PATH_AUTO, pb.PathAlgorithm_A_STAR, pb.PathAlgorithm_BELLMAN_FORD} {
+		path, err := s.ShortestPath(ctx, &pb.PathRequest{Gid: id, S: 1, T: 4, Algorithm: algo})
+		if err != nil || !Equal(path.Path, expected.Path) || path.Cost != expected.Cost {
+			t.Error(algo, ": expected", expected, "received", path, err)
+		}
+	}
+	if _, err := s.KShortestPaths(ctx, &pb.KPathsRequest{Gid: id, S: 1, T: 4, K: 2}); status.Code(err) != codes.FailedPrecondition {
+		t.Error("k shortest paths: expected", codes.FailedPrecondition, "received", err)
+	}
+	if _, err := s.KShortestPaths(ctx, &pb.KPathsRequest{Gid: id, S: 1, T: 4, K: 0}); status.Code(err) != codes.InvalidArgument {
+		t.Error("k shortest paths: expected", codes.InvalidArgument, "received", err)
+	}
+
+	// Close the negative cycle 2 -> 3 -> 2
+	if _, err := s.AddEdges(ctx, &pb.EdgesRequest{Gid: id, Edges: []*pb.Edge{
+		{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 2}}}); err != nil {
+		t.Fatal("cannot add edge", err)
+	}
+	_, err := s.ShortestPath(ctx, &pb.PathRequest{Gid: id, S: 1, T: 4})
+	er, _ := status.FromError(err)
+	if er.Code() != codes.FailedPrecondition {
+		t.Fatal("error code: expected", codes.FailedPrecondition, "received", err)
+	}
+	var cycle *pb.NegativeCycle
+	for _, d := range er.Details() {
+		if c, ok := d.(*pb.NegativeCycle); ok {
+			cycle = c
+		}
+	}
+	if cycle == nil || len(cycle.Vertices) != 2 || cycle.Vertices[0]+cycle.Vertices[1] != 5 {
+		t.Error("cycle: expected 2 and 3, received", cycle)
+	}
+
+	// 4 reaches no cycle
+	if _, err := s.ShortestPathTree(ctx, &pb.TreeRequest{Gid: id, Source: 4}); err != nil {
+		t.Error("tree from 4: unexpected error", err)
+	}
+
+	// Undirected graphs cannot have negative weights
+	uid, _ := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2}})
+	_, err = s.AddEdges(ctx, &pb.EdgesRequest{Gid: uid, Edges: []*pb.Edge{
+		{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: -1}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Error("undirected negative edge: expected", codes.InvalidArgument, "received", err)
+	}
+}
+
+func TestGraphServer_BatchShortestPath(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Post a path 1 - 2 - 3 and an isolated vertex 4
+	g := &pb.Graph{Vertices: []int32{1, 2, 3, 4},
+		Edges: map[int32]*pb.Neighbors{
+			1: {Neighbors: []int32{2}},
+			2: {Neighbors: []int32{3}},
+		}}
+
+	id, err0 := s.PostGraph(ctx, g)
+	if err0 != nil {
+		t.Fatal("cannot post graph", err0)
+	}
+
+	pairs := []*pb.VertexPair{
+		{S: 1, T: 3},
+		{S: 3, T: 1},
+		{S: 1, T: 4},
+		{S: 5, T: 1},
+		{S: 1, T: 6},
+		{S: 1, T: 1},
+		{S: 3, T: 2},
+	}
+	tests := []struct {
+		path []int32
+		cost int64
+		code codes.Code
+	}{
+		{[]int32{1, 2, 3}, 2, codes.OK},
+		{[]int32{3, 2, 1}, 2, codes.OK},
+		{nil, 0, codes.NotFound},
+		{nil, 0, codes.NotFound},
+		{nil, 0, codes.NotFound},
+		{[]int32{1}, 0, codes.OK},
+		{[]int32{3, 2}, 1, codes.OK},
+	}
+
+	res, err := s.BatchShortestPath(ctx, &pb.BatchPathRequest{Gid: id, Pairs: pairs})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	if len(res.Results) != len(tests) {
+		t.Fatal("results: expected", len(tests), "received", len(res.Results))
+	}
+
+	for i, tt := range tests {
+		r := res.Results[i]
+		if tt.code != codes.OK {
+			if r.GetError() == nil || codes.Code(r.GetError().Code) != tt.code {
+				t.Error("pair", pairs[i], ": expected", tt.code, "received", r)
+			}
+			continue
+		}
+		if !Equal(r.GetPath().GetPath(), tt.path) || r.GetPath().GetCost() != tt.cost {
+			t.Error("pair", pairs[i], ": expected", tt.path, "with cost", tt.cost, "received", r)
+		}
+	}
+
+	if msg := res.Results[3].GetError().GetMessage(); msg != "non-existant node" {
+		t.Error("error message: expected", "non-existant node", "received", msg)
+	}
+	if msg := res.Results[2].GetError().GetMessage(); msg != "no path between nodes" {
+		t.Error("error message: expected", "no path between nodes", "received", msg)
+	}
+
+	// The details of each error are those ShortestPath would give
+	var noPath *errdetails.ErrorInfo
+	var missing *errdetails.ResourceInfo
+	for _, r := range []*pb.PathResult{res.Results[2], res.Results[3]} {
+		for _, d := range r.GetError().GetDetails() {
+			m, err := d.UnmarshalNew()
+			if err != nil {
+				t.Fatal("cannot read error details", err)
+			}
+			switch m := m.(type) {
+			case *errdetails.ErrorInfo:
+				noPath = m
+			case *errdetails.ResourceInfo:
+				missing = m
+			}
+		}
+	}
+	if noPath.GetReason() != "NO_PATH" {
+		t.Error("no path details: expected reason", "NO_PATH", "received", noPath)
+	}
+	if missing.GetResourceType() != "vertex" || missing.GetResourceName() != "5" {
+		t.Error("missing vertex details: expected vertex", 5, "received", missing)
+	}
+
+	// One search for each of the sources 1 and 3
+	if st, _ := s.GetCacheStats(ctx, &pb.CacheStatsRequest{}); st.Misses != 2 {
+		t.Error("searches: expected", 2, "received", st.Misses)
+	}
+
+	// A missing graph still fails the whole batch
+	_, err = s.BatchShortestPath(ctx, &pb.BatchPathRequest{Gid: &pb.GraphID{Id: 100}, Pairs: pairs})
+	if status.Code(err) != codes.NotFound {
+		t.Error("missing graph: expected", codes.NotFound, "received", err)
+	}
+}
+
+// Test the ShortestPath function when there may be no path
+func TestGraphServer_NoPath(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Post a graph with two components, 1-2-3 and 4-5,
+	// and an isolated node 6
+	g := &pb.Graph{Vertices: []int32{1, 2, 3, 4, 5, 6},
+		Edges: map[int32]*pb.Neighbors{
+			1: {Neighbors: []int32{2}},
+			2: {Neighbors: []int32{3}},
+			4: {Neighbors: []int32{5}},
+		}}
+
+	id, err0 := s.PostGraph(ctx, g)
+	if err0 != nil {
+		t.Error("cannot post graph", err0)
+	}
+
+	tests := []struct {
+		name string
+		req  *pb.PathRequest
+		res  *pb.Path
+		code codes.Code
+	}{
+		{
+			"self path",
+			&pb.PathRequest{S: 2, T: 2, Gid: id},
+			&pb.Path{Path: []int32{2}},
+			codes.OK,
+		},
+		{
+			"isolated self path",
+			&pb.PathRequest{S: 6, T: 6, Gid: id},
+			&pb.Path{Path: []int32{6}},
+			codes.OK,
+		},
+		{
+			"different components",
+			&pb.PathRequest{S: 1, T: 5, Gid: id},
+			nil,
+			codes.NotFound,
+		},
+		{
+			"from isolated node",
+			&pb.PathRequest{S: 6, T: 3, Gid: id},
+			nil,
+			codes.NotFound,
+		},
+		{
+			"to isolated node",
+			&pb.PathRequest{S: 4, T: 6, Gid: id},
+			nil,
+			codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			path, err := s.ShortestPath(ctx, tt.req)
+
+			er, _ := status.FromError(err)
+			if er.Code() != tt.code {
+				t.Fatal("code: expected", tt.code, "received", er.Code())
+			}
+
+			if tt.code == codes.OK {
+				if !Equal(path.Path, tt.res.Path) || path.Cost != 0 {
+					t.Error("response: expected", tt.res.Path, "received", path.Path, path.Cost)
+				}
+				return
+			}
+
+			// The error names the unreachable pair
+			if len(er.Details()) != 1 {
+				t.Fatal("details: expected 1 received", len(er.Details()))
+			}
+			info, ok := er.Details()[0].(*errdetails.ErrorInfo)
+			if !ok || info.Reason != "NO_PATH" {
+				t.Fatal("details: expected NO_PATH ErrorInfo received", er.Details()[0])
+			}
+			if info.Metadata["source"] != strconv.Itoa(int(tt.req.S)) ||
+				info.Metadata["target"] != strconv.Itoa(int(tt.req.T)) {
+				t.Error("details: expected", tt.req.S, tt.req.T, "received", info.Metadata)
+			}
+		})
+	}
+}
+
+// Test the AddVertices, RemoveVertices, AddEdges and RemoveEdges functions
+func TestGraphServer_Mutations(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Start from the path 1 - 2 - 3
+	id, err0 := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2, 3},
+		Edges: map[int32]*pb.Neighbors{
+			1: {Neighbors: []int32{2}},
+			2: {Neighbors: []int32{3}},
+		}})
+	if err0 != nil {
+		t.Error("cannot post graph", err0)
+	}
+
+	edge := func(v1, v2, w int32) *pb.Edge {
+		return &pb.Edge{V1: &pb.Vertex{Id: v1}, V2: &pb.Vertex{Id: v2}, Weight: w}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func() (*pb.MutationReply, error)
+		res    *pb.MutationReply
+		code   codes.Code
+		req    *pb.PathRequest
+		path   *pb.Path
+	}{
+		{
+			"add vertices",
+			func() (*pb.MutationReply, error) {
+				return s.AddVertices(ctx, &pb.VerticesRequest{Gid: id, Vertices: []int32{4, 5}})
+			},
+			&pb.MutationReply{VertexCount: 5, EdgeCount: 2},
+			codes.OK,
+			nil,
+			nil,
+		},
+		{
+			"add edges",
+			func() (*pb.MutationReply, error) {
+				return s.AddEdges(ctx, &pb.EdgesRequest{Gid: id,
+					Edges: []*pb.Edge{edge(3, 4, 1), edge(1, 4, 5)}})
+			},
+			&pb.MutationReply{VertexCount: 5, EdgeCount: 4},
+			codes.OK,
+			&pb.PathRequest{S: 1, T: 4, Gid: id},
+			&pb.Path{Path: []int32{1, 2, 3, 4}, Cost: 3},
+		},
+		{
+			"change the weight of an edge",
+			func() (*pb.MutationReply, error) {
+				return s.AddEdges(ctx, &pb.EdgesRequest{Gid: id, Edges: []*pb.Edge{edge(4, 1, 2)}})
+			},
+			&pb.MutationReply{VertexCount: 5, EdgeCount: 4},
+			codes.OK,
+			&pb.PathRequest{S: 1, T: 4, Gid: id},
+			&pb.Path{Path: []int32{1, 4}, Cost: 2},
+		},
+		{
+			"remove edges",
+			func() (*pb.MutationReply, error) {
+				return s.RemoveEdges(ctx, &pb.EdgesRequest{Gid: id, Edges: []*pb.Edge{edge(1, 4, 0)}})
+			},
+			&pb.MutationReply{VertexCount: 5, EdgeCount: 3},
+			codes.OK,
+			&pb.PathRequest{S: 1, T: 4, Gid: id},
+			&pb.Path{Path: []int32{1, 2, 3, 4}, Cost: 3},
+		},
+		{
+			"remove vertices",
+			func() (*pb.MutationReply, error) {
+				return s.RemoveVertices(ctx, &pb.VerticesRequest{Gid: id, Vertices: []int32{2}})
+			},
+			&pb.MutationReply{VertexCount: 4, EdgeCount: 1},
+			codes.OK,
+			&pb.PathRequest{S: 3, T: 4, Gid: id},
+			&pb.Path{Path: []int32{3, 4}, Cost: 1},
+		},
+		{
+			"add existing vertex",
+			func() (*pb.MutationReply, error) {
+				return s.AddVertices(ctx, &pb.VerticesRequest{Gid: id, Vertices: []int32{6, 3}})
+			},
+			nil,
+			codes.AlreadyExists,
+			nil,
+			nil,
+		},
+		{
+			"add edge to non-existant vertex",
+			func() (*pb.MutationReply, error) {
+				return s.AddEdges(ctx, &pb.EdgesRequest{Gid: id,
+					Edges: []*pb.Edge{edge(1, 5, 1), edge(1, 2, 1)}})
+			},
+			nil,
+			codes.NotFound,
+			nil,
+			nil,
+		},
+		{
+			"remove non-existant edge",
+			func() (*pb.MutationReply, error) {
+				return s.RemoveEdges(ctx, &pb.EdgesRequest{Gid: id,
+					Edges: []*pb.Edge{edge(3, 4, 0), edge(1, 3, 0)}})
+			},
+			nil,
+			codes.NotFound,
+			nil,
+			nil,
+		},
+		{
+			"remove vertices from non-existant graph",
+			func() (*pb.MutationReply, error) {
+				return s.RemoveVertices(ctx, &pb.VerticesRequest{Gid: &pb.GraphID{Id: 42}, Vertices: []int32{1}})
+			},
+			nil,
+			codes.NotFound,
+			nil,
+			nil,
+		},
+		{
+			"failed mutations leave the graph unchanged",
+			func() (*pb.MutationReply, error) {
+				return s.AddVertices(ctx, &pb.VerticesRequest{Gid: id})
+			},
+			&pb.MutationReply{VertexCount: 4, EdgeCount: 1},
+			codes.OK,
+			&pb.PathRequest{S: 3, T: 4, Gid: id},
+			&pb.Path{Path: []int32{3, 4}, Cost: 1},
+		},
 	}
 
-	ctx := context.Background()
-	s := newServer()
-
-	// Check the output of the function
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 
-			id, err := s.PostGraph(ctx, tt.graph)
+			rep, err := tt.mutate()
 
-			if id != nil {
-				if id.Id != tt.res.Id {
-					t.Error("response: expected", tt.res.Id, "received", id.Id)
-				}
+			er, _ := status.FromError(err)
+			if er.Code() != tt.code {
+				t.Fatal("code: expected", tt.code, "received", er.Code(), er.Message())
+			}
+			if tt.res != nil && (rep.VertexCount != tt.res.VertexCount || rep.EdgeCount != tt.res.EdgeCount) {
+				t.Error("response: expected", tt.res, "received", rep)
 			}
 
-			if err != nil {
-				if er, ok := status.FromError(err); ok {
-					if er.Message() != tt.errMsg {
-						t.Error("error message: expected", tt.errMsg, "received", er.Message())
-					}
+			// Check that searches see the mutation
+			if tt.req != nil {
+				path, err := s.ShortestPath(ctx, tt.req)
+				if err != nil {
+					t.Fatal("unexpected error", err)
+				}
+				if !Equal(path.Path, tt.path.Path) || path.Cost != tt.path.Cost {
+					t.Error("path: expected", tt.path, "received", path)
 				}
 			}
 		})
 	}
 }
 
-// Test the ShortestPath function
-func TestGraphServer_ShortestPath(t *testing.T) {
+// Test that GetGraph returns a graph that posts back identically
+func TestGraphServer_GetGraph(t *testing.T) {
 
 	ctx := context.Background()
 	s := newServer()
 
-	// First, post a graph to the server
-	g := &pb.Graph{Vertices: []int32{1, 2, 3, 4, 5},
+	g := &pb.Graph{Vertices: []int32{1, 2, 3},
 		Edges: map[int32]*pb.Neighbors{
-			1: {Neighbors: []int32{2, 3, 4, 5}},
-			2: {Neighbors: []int32{1}},
-			3: {Neighbors: []int32{1}},
-			4: {Neighbors: []int32{1}},
-			5: {Neighbors: []int32{1}},
-		}}
+			1: {Neighbors: []int32{2}},
+		},
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 2}, Weight: 7},
+		},
+		Directed: true}
 
 	id, err0 := s.PostGraph(ctx, g)
+	if err0 != nil {
+		t.Fatal("cannot post graph", err0)
+	}
+
+	res, err := s.GetGraph(ctx, id)
+	if err != nil {
+		t.Fatal("cannot get graph", err)
+	}
+
+	if !Equal(res.Vertices, g.Vertices) || !res.Directed {
+		t.Error("vertices: expected", g.Vertices, "received", res.Vertices, res.Directed)
+	}
+	expected := []string{"1->2 (1)", "3->2 (7)"}
+	if len(res.WeightedEdges) != len(expected) {
+		t.Fatal("edges: expected", expected, "received", res.WeightedEdges)
+	}
+	for i, e := range res.WeightedEdges {
+		if received := fmt.Sprintf("%d->%d (%d)", e.V1.Id, e.V2.Id, e.Weight); received != expected[i] {
+			t.Error("edge: expected", expected[i], "received", received)
+		}
+	}
+
+	// Posting the graph back keeps the direction of its edges
+	id2, err := s.PostGraph(ctx, res)
+	if err != nil {
+		t.Fatal("cannot post graph back", err)
+	}
+	if _, err := s.ShortestPath(ctx, &pb.PathRequest{S: 2, T: 1, Gid: id2}); status.Code(err) != codes.NotFound {
+		t.Error("code: expected", codes.NotFound, "received", status.Code(err))
+	}
+
+	if _, err := s.GetGraph(ctx, &pb.GraphID{Id: 42}); status.Code(err) != codes.NotFound {
+		t.Error("code: expected", codes.NotFound, "received", status.Code(err))
+	}
+}
+
+// Test the pages of ListGraphs
+func TestGraphServer_ListGraphs(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Post graphs 1 to 5 with i vertices each, and delete graph 2
+	for i := 1; i <= 5; i++ {
+		g := &pb.Graph{}
+		for v := 1; v <= i; v++ {
+			g.Vertices = append(g.Vertices, int32(v))
+		}
+		if _, err := s.PostGraph(ctx, g); err != nil {
+			t.Fatal("cannot post graph", err)
+		}
+	}
+	if _, err := s.DeleteGraph(ctx, &pb.GraphID{Id: 2}); err != nil {
+		t.Fatal("cannot delete graph", err)
+	}
+
+	tests := []struct {
+		name  string
+		req   *pb.ListGraphsRequest
+		ids   []int32
+		token string
+	}{
+		{"all graphs", &pb.ListGraphsRequest{}, []int32{1, 3, 4, 5}, ""},
+		{"first page", &pb.ListGraphsRequest{PageSize: 2}, []int32{1, 3}, "4"},
+		{"last page", &pb.ListGraphsRequest{PageSize: 2, PageToken: "4"}, []int32{4, 5}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			res, err := s.ListGraphs(ctx, tt.req)
+			if err != nil {
+				t.Fatal("unexpected error", err)
+			}
+
+			var ids []int32
+			for _, info := range res.Graphs {
+				ids = append(ids, info.Gid.Id)
+				if info.VertexCount != info.Gid.Id || info.EdgeCount != 0 {
+					t.Error("size: expected", info.Gid.Id, 0, "received", info.VertexCount, info.EdgeCount)
+				}
+				if info.CreatedAt.AsTime().IsZero() {
+					t.Error("graph", info.Gid.Id, "has no creation time")
+				}
+			}
+			if !Equal(ids, tt.ids) {
+				t.Error("ids: expected", tt.ids, "received", ids)
+			}
+			if res.NextPageToken != tt.token {
+				t.Error("token: expected", tt.token, "received", res.NextPageToken)
+			}
+		})
+	}
+
+	_, err := s.ListGraphs(ctx, &pb.ListGraphsRequest{PageToken: "not a token"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Error("code: expected", codes.InvalidArgument, "received", status.Code(err))
+	}
+}
+
+// Test the status codes and details of failed requests
+func TestGraphServer_ErrorStatus(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	id, err0 := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2},
+		Edges: map[int32]*pb.Neighbors{1: {Neighbors: []int32{2}}}})
 	if err0 != nil {
 		t.Error("cannot post graph", err0)
 	}
 
-	// Query for 2 shortest paths
 	tests := []struct {
 		name   string
-		req    *pb.PathRequest
-		res    *pb.Path
-		errMsg string
+		call   func() error
+		code   codes.Code
+		detail string
 	}{
 		{
-			"base case",
-			&pb.PathRequest{S: 1, T: 2, Gid: id},
-			&pb.Path{Path: []int32{1, 2}},
-			"",
+			"path in non-existant graph",
+			func() error {
+				_, err := s.ShortestPath(ctx, &pb.PathRequest{S: 1, T: 2, Gid: &pb.GraphID{Id: 42}})
+				return err
+			},
+			codes.NotFound,
+			"graph graphs/42",
 		},
 		{
-			"longer path",
-			&pb.PathRequest{S: 3, T: 2, Gid: id},
-			&pb.Path{Path: []int32{3, 1, 2}},
-			"found edge between non-existant nodes",
+			"path without graph ID",
+			func() error {
+				_, err := s.ShortestPath(ctx, &pb.PathRequest{S: 1, T: 2})
+				return err
+			},
+			codes.InvalidArgument,
+			"field gid",
+		},
+		{
+			"path from non-existant node",
+			func() error {
+				_, err := s.ShortestPath(ctx, &pb.PathRequest{S: 7, T: 2, Gid: id})
+				return err
+			},
+			codes.NotFound,
+			"vertex 7",
+		},
+		{
+			"path to non-existant node",
+			func() error {
+				_, err := s.ShortestPath(ctx, &pb.PathRequest{S: 1, T: 8, Gid: id})
+				return err
+			},
+			codes.NotFound,
+			"vertex 8",
+		},
+		{
+			"edge between non-existant nodes",
+			func() error {
+				_, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1},
+					Edges: map[int32]*pb.Neighbors{1: {Neighbors: []int32{3}}}})
+				return err
+			},
+			codes.InvalidArgument,
+			"field edges[1].neighbors[0]",
+		},
+		{
+			"duplicate vertices",
+			func() error {
+				_, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 1}})
+				return err
+			},
+			codes.InvalidArgument,
+			"field vertices[1]",
+		},
+		{
+			"delete non-existant graph",
+			func() error {
+				_, err := s.DeleteGraph(ctx, &pb.GraphID{Id: 42})
+				return err
+			},
+			codes.NotFound,
+			"graph graphs/42",
 		},
 	}
 
-	// Check the output of the function
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			er, _ := status.FromError(tt.call())
 
-			path, err := s.ShortestPath(ctx, tt.req)
-
-			if path != nil {
-				if !Equal(path.Path, tt.res.Path) {
-					t.Error("response: expected", tt.res.Path, "received", path.Path)
-				}
+			if er.Code() != tt.code {
+				t.Error("code: expected", tt.code, "received", er.Code())
 			}
-
-			if err != nil {
-				if er, ok := status.FromError(err); ok {
-					if er.Message() != tt.errMsg {
-						t.Error("error message: expected", tt.errMsg, "received", er.Message())
-					}
-				}
+			if detail := describeDetail(er); detail != tt.detail {
+				t.Error("detail: expected", tt.detail, "received", detail)
 			}
 		})
 	}
 }
 
+// describeDetail summarizes the first detail of a status
+func describeDetail(er *status.Status) string {
+	if len(er.Details()) == 0 {
+		return ""
+	}
+	switch d := er.Details()[0].(type) {
+	case *errdetails.ResourceInfo:
+		return d.ResourceType + " " + d.ResourceName
+	case *errdetails.BadRequest:
+		return "field " + d.FieldViolations[0].Field
+	case *errdetails.ErrorInfo:
+		return "reason " + d.Reason
+	}
+	return "unknown"
+}
+
 // Test the DeleteGraph function
 func TestGraphServer_DeleteGraph(t *testing.T) {
 
@@ -239,3 +1312,356 @@ func TestGraphServer_DeleteGraph(t *testing.T) {
 		})
 	}
 }
+
+func TestGraphServer_Components(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Two islands, 1 -> 2 -> 3 and 4 -> 5, and a lone vertex 6
+	id, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2, 3, 4, 5, 6}, Directed: true,
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 1},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 1},
+			{V1: &pb.Vertex{Id: 5}, V2: &pb.Vertex{Id: 4}, Weight: 1},
+		}})
+	if err != nil {
+		t.Fatal("cannot post graph", err)
+	}
+
+	comps, err := s.ConnectedComponents(ctx, id)
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	want := [][]int32{{1, 2, 3}, {4, 5}, {6}}
+	if len(comps.Components) != len(want) {
+		t.Fatal("components: expected", want, "received", comps.Components)
+	}
+	for k, c := range comps.Components {
+		if fmt.Sprint(c.Vertices) != fmt.Sprint(want[k]) {
+			t.Error("component", k, ": expected", want[k], "received", c.Vertices)
+		}
+	}
+
+	tests := []struct {
+		s, t                 int32
+		reachable, connected bool
+	}{
+		{1, 3, true, true},
+		{3, 1, false, true},
+		{4, 5, false, true},
+		{1, 4, false, false},
+		{6, 6, true, true},
+	}
+	for _, tt := range tests {
+		res, err := s.IsReachable(ctx, &pb.ReachRequest{Gid: id, S: tt.s, T: tt.t})
+		if err != nil || res.Reachable != tt.reachable || res.Connected != tt.connected {
+			t.Error("from", tt.s, "to", tt.t, ": expected", tt.reachable, tt.connected, "received", res, err)
+		}
+	}
+
+	// The cached components follow the changes of the graph
+	if _, err := s.AddEdges(ctx, &pb.EdgesRequest{Gid: id, Edges: []*pb.Edge{
+		{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 4}, Weight: 1}}}); err != nil {
+		t.Fatal("cannot add edge", err)
+	}
+	if res, err := s.IsReachable(ctx, &pb.ReachRequest{Gid: id, S: 1, T: 4}); err != nil || !res.Reachable {
+		t.Error("from 1 to 4 after the change: expected reachable, received", res, err)
+	}
+	if comps, _ := s.ConnectedComponents(ctx, id); len(comps.Components) != 2 {
+		t.Error("components after the change: expected 2, received", comps.Components)
+	}
+
+	_, err = s.IsReachable(ctx, &pb.ReachRequest{Gid: id, S: 1, T: 7})
+	if er, _ := status.FromError(err); er.Code() != codes.NotFound {
+		t.Error("missing vertex: expected NotFound, received", err)
+	}
+	_, err = s.ConnectedComponents(ctx, &pb.GraphID{Id: id.Id + 1})
+	if er, _ := status.FromError(err); er.Code() != codes.NotFound {
+		t.Error("missing graph: expected NotFound, received", err)
+	}
+}
+
+func TestGraphServer_StronglyConnectedComponents(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Services 1, 2 and 3 depend on each other, and on 4,
+	// which depends on 5
+	id, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2, 3, 4, 5}, Directed: true,
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 1},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 1},
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 1}, Weight: 1},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 4}, Weight: 7},
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 4}, Weight: 5},
+			{V1: &pb.Vertex{Id: 4}, V2: &pb.Vertex{Id: 5}, Weight: 2},
+		}})
+	if err != nil {
+		t.Fatal("cannot post graph", err)
+	}
+
+	res, err := s.StronglyConnectedComponents(ctx, &pb.SCCRequest{Gid: id, StoreCondensation: true})
+	if err != nil {
+		t.Fatal("unexpected error", err)
+	}
+	want := [][]int32{{1, 2, 3}, {4}, {5}}
+	if len(res.Components) != len(want) {
+		t.Fatal("components: expected", want, "received", res.Components)
+	}
+	for k, c := range res.Components {
+		if fmt.Sprint(c.Vertices) != fmt.Sprint(want[k]) {
+			t.Error("component", k, ": expected", want[k], "received", c.Vertices)
+		}
+	}
+
+	// The condensation is returned, and stored under a new ID
+	dag := &pb.Graph{Vertices: []int32{0, 1, 2}, Directed: true,
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 0}, V2: &pb.Vertex{Id: 1}, Weight: 5},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 2},
+		}}
+	if !proto.Equal(res.Condensation, dag) {
+		t.Error("condensation: expected", dag, "received", res.Condensation)
+	}
+	if res.CondensationId == nil || res.CondensationId.Id == id.Id {
+		t.Fatal("condensation ID: expected a new ID, received", res.CondensationId)
+	}
+	stored, err := s.GetGraph(ctx, res.CondensationId)
+	if err != nil || !proto.Equal(stored, dag) {
+		t.Error("stored condensation: expected", dag, "received", stored, err)
+	}
+
+	// Nothing is stored unless requested
+	if res, _ := s.StronglyConnectedComponents(ctx, &pb.SCCRequest{Gid: id}); res.CondensationId != nil {
+		t.Error("condensation ID: expected none, received", res.CondensationId)
+	}
+}
+
+func TestGraphServer_TopologicalSort(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// A build: 1 and 2 are compiled, then linked by 3, which 4 and 5
+	// package. 5 also needs the docs, 6
+	id, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2, 3, 4, 5, 6}, Directed: true,
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 3}, Weight: 1},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 1},
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 4}, Weight: 1},
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 5}, Weight: 1},
+			{V1: &pb.Vertex{Id: 6}, V2: &pb.Vertex{Id: 5}, Weight: 1},
+		}})
+	if err != nil {
+		t.Fatal("cannot post graph", err)
+	}
+
+	res, err := s.TopologicalSort(ctx, &pb.TopoRequest{Gid: id})
+	if want := "[1 2 3 4 6 5]"; err != nil || fmt.Sprint(res.Order) != want || res.Layers != nil {
+		t.Error("order: expected", want, "received", res, err)
+	}
+
+	res, err = s.TopologicalSort(ctx, &pb.TopoRequest{Gid: id, Layers: true})
+	want := []string{"[1 2 6]", "[3]", "[4 5]"}
+	if err != nil || len(res.Layers) != len(want) || res.Order != nil {
+		t.Fatal("layers: expected", want, "received", res, err)
+	}
+	for i, l := range res.Layers {
+		if fmt.Sprint(l.Vertices) != want[i] {
+			t.Error("layer", i, ": expected", want[i], "received", l.Vertices)
+		}
+	}
+
+	// Close the cycle 2 -> 3 -> 5 -> 2
+	if _, err := s.AddEdges(ctx, &pb.EdgesRequest{Gid: id, Edges: []*pb.Edge{
+		{V1: &pb.Vertex{Id: 5}, V2: &pb.Vertex{Id: 2}, Weight: 1}}}); err != nil {
+		t.Fatal("cannot add edge", err)
+	}
+	for _, layers := range []bool{false, true} {
+		_, err = s.TopologicalSort(ctx, &pb.TopoRequest{Gid: id, Layers: layers})
+		er, _ := status.FromError(err)
+		if er.Code() != codes.FailedPrecondition || er.Message() != "graph has a cycle" {
+			t.Fatal("layers", layers, ": expected", "graph has a cycle", "received", err)
+		}
+		var cycle *pb.Cycle
+		for _, d := range er.Details() {
+			if c, ok := d.(*pb.Cycle); ok {
+				cycle = c
+			}
+		}
+		if cycle == nil || cycle.Gid.Id != id.Id || len(cycle.Vertices) != 3 {
+			t.Error("layers", layers, ": cycle: expected 2, 3 and 5, received", cycle)
+		}
+	}
+
+	uid, _ := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2}})
+	_, err = s.TopologicalSort(ctx, &pb.TopoRequest{Gid: uid})
+	if er, _ := status.FromError(err); er.Message() != "graph is not directed" {
+		t.Error("undirected: expected", "graph is not directed", "received", err)
+	}
+}
+
+func TestGraphServer_MinimumSpanningTree(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Sites 1 to 4 can be linked in a square, with a costly
+	// diagonal. Sites 5 and 6 are on an island, and 7 is alone
+	g := &pb.Graph{Vertices: []int32{1, 2, 3, 4, 5, 6, 7},
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 4},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 1},
+			{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 4}, Weight: 3},
+			{V1: &pb.Vertex{Id: 4}, V2: &pb.Vertex{Id: 1}, Weight: 2},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 3}, Weight: 5},
+			{V1: &pb.Vertex{Id: 5}, V2: &pb.Vertex{Id: 6}, Weight: 6},
+		},
+		Positions: map[int32]*pb.Point{1: {X: 0, Y: 0}, 7: {X: 5, Y: 5}},
+	}
+	id, err := s.PostGraph(ctx, g)
+	if err != nil {
+		t.Fatal("cannot post graph", err)
+	}
+
+	tests := []struct {
+		name      string
+		algorithm pb.MSTAlgorithm
+		edges     string
+	}{
+		{"kruskal", pb.MSTAlgorithm_KRUSKAL, "[2-3 1-4 3-4 5-6]"},
+		{"prim", pb.MSTAlgorithm_PRIM, "[1-4 3-4 2-3 5-6]"},
+	}
+	for _, tt := range tests {
+		res, err := s.MinimumSpanningTree(ctx, &pb.MSTRequest{Gid: id, Algorithm: tt.algorithm, Store: true})
+		if err != nil {
+			t.Fatal(tt.name, ": unexpected error", err)
+		}
+		var edges []string
+		for _, e := range res.Edges {
+			edges = append(edges, fmt.Sprintf("%d-%d", e.V1.Id, e.V2.Id))
+		}
+		if fmt.Sprint(edges) != tt.edges || res.Weight != 12 {
+			t.Error(tt.name, ": expected", tt.edges, "of weight 12, received", edges, res.Weight)
+		}
+
+		// The stored forest keeps every vertex and its position
+		stored, err := s.GetGraph(ctx, res.ForestId)
+		if err != nil {
+			t.Fatal(tt.name, ": cannot get the forest", err)
+		}
+		if len(stored.Vertices) != 7 || len(stored.WeightedEdges) != 4 || !proto.Equal(stored.Positions[7], g.Positions[7]) {
+			t.Error(tt.name, ": stored forest: expected 7 vertices and 4 edges, received", stored)
+		}
+	}
+
+	if res, _ := s.MinimumSpanningTree(ctx, &pb.MSTRequest{Gid: id}); res.ForestId != nil {
+		t.Error("forest ID: expected none, received", res.ForestId)
+	}
+
+	did, _ := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2}, Directed: true})
+	_, err = s.MinimumSpanningTree(ctx, &pb.MSTRequest{Gid: did})
+	if er, _ := status.FromError(err); er.Message() != "graph is directed" {
+		t.Error("directed: expected", "graph is directed", "received", err)
+	}
+}
+
+func TestGraphServer_MaxFlow(t *testing.T) {
+
+	ctx := context.Background()
+	s := newServer()
+
+	// Pipes from 1 to 4 through 2 and 3, the last of
+	// which is added by a mutation
+	id, err := s.PostGraph(ctx, &pb.Graph{Vertices: []int32{1, 2, 3, 4}, Directed: true,
+		WeightedEdges: []*pb.Edge{
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 1, Capacity: 3},
+			{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 3}, Weight: 1, Capacity: 2},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 3}, Weight: 1, Capacity: 5},
+			{V1: &pb.Vertex{Id: 2}, V2: &pb.Vertex{Id: 4}, Weight: 1, Capacity: 2},
+		},
+	})
+	if err != nil {
+		t.Fatal("cannot post graph", err)
+	}
+	_, err = s.AddEdges(ctx, &pb.EdgesRequest{Gid: id, Edges: []*pb.Edge{
+		{V1: &pb.Vertex{Id: 3}, V2: &pb.Vertex{Id: 4}, Weight: 1, Capacity: 3},
+	}})
+	if err != nil {
+		t.Fatal("cannot add edge", err)
+	}
+
+	for _, algorithm := range []pb.FlowAlgorithm{pb.FlowAlgorithm_DINIC, pb.FlowAlgorithm_PUSH_RELABEL} {
+		res, err := s.MaxFlow(ctx, &pb.FlowRequest{Gid: id, Source: 1, Sink: 4, Algorithm: algorithm})
+		if err != nil {
+			t.Fatal(algorithm, ": unexpected error", err)
+		}
+		if res.Value != 5 || fmt.Sprint(res.SourceSide) != "[1]" || fmt.Sprint(res.SinkSide) != "[2 3 4]" {
+			t.Error(algorithm, ": expected a flow of 5 cut between [1] and [2 3 4], received", res)
+		}
+		into := int64(0)
+		for _, e := range res.Edges {
+			if e.V2.Id == 4 {
+				into += e.Flow
+			}
+		}
+		if into != 5 {
+			t.Error(algorithm, ": expected a flow of 5 into the sink, received", into)
+		}
+	}
+
+	_, err = s.MaxFlow(ctx, &pb.FlowRequest{Gid: id, Source: 1, Sink: 1})
+	if er, _ := status.FromError(err); er.Code() != codes.InvalidArgument {
+		t.Error("same vertex: expected", codes.InvalidArgument, "received", err)
+	}
+	_, err = s.MaxFlow(ctx, &pb.FlowRequest{Gid: id, Source: 1, Sink: 9})
+	if er, _ := status.FromError(err); er.Code() != codes.NotFound {
+		t.Error("missing sink: expected", codes.NotFound, "received", err)
+	}
+	_, err = s.AddEdges(ctx, &pb.EdgesRequest{Gid: id, Edges: []*pb.Edge{
+		{V1: &pb.Vertex{Id: 4}, V2: &pb.Vertex{Id: 1}, Capacity: -1},
+	}})
+	if er, _ := status.FromError(err); er.Message() != "found edge with negative capacity" {
+		t.Error("negative capacity: expected", "found edge with negative capacity", "received", err)
+	}
+}
+
+// allPairsStream collects the rows sent by AllPairsShortestPaths
+type allPairsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	rows []*pb.DistanceRow
+}
+
+func (st *allPairsStream) Context() context.Context {
+	return st.ctx
+}
+
+func (st *allPairsStream) Send(row *pb.DistanceRow) error {
+	st.rows = append(st.rows, row)
+	return nil
+}
+
+// Test that AllPairsShortestPaths stops with a Canceled status once
+// the client is gone
+func TestGraphServer_AllPairsCanceled(t *testing.T) {
+
+	s := newServer()
+	id, err := s.PostGraph(context.Background(), &pb.Graph{Vertices: []int32{1, 2},
+		WeightedEdges: []*pb.Edge{{V1: &pb.Vertex{Id: 1}, V2: &pb.Vertex{Id: 2}, Weight: 1}}})
+	if err != nil {
+		t.Fatal("cannot post graph", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, algorithm := range []pb.AllPairsAlgorithm{pb.AllPairsAlgorithm_FLOYD_WARSHALL, pb.AllPairsAlgorithm_JOHNSON} {
+		stream := &allPairsStream{ctx: ctx}
+		err := s.AllPairsShortestPaths(&pb.AllPairsRequest{Gid: id, Algorithm: algorithm}, stream)
+		if status.Code(err) != codes.Canceled || len(stream.rows) != 0 {
+			t.Error(algorithm, ": expected", codes.Canceled, "and no rows, received", err, "and", len(stream.rows), "rows")
+		}
+	}
+}
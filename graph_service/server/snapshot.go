@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// readSnapshot reads the snapshot at [path]. A missing file is the
+// snapshot of a server that has no graphs yet
+func readSnapshot(path string) (*pb.Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &pb.Snapshot{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The snapshot is renamed into place once complete,
+	// so a bad frame is not left by a crash
+	payload, n := readFrame(data)
+	if n == 0 || n != len(data) {
+		return nil, fmt.Errorf("corrupted snapshot %s", path)
+	}
+	snap := new(pb.Snapshot)
+	if err := proto.Unmarshal(payload, snap); err != nil {
+		return nil, fmt.Errorf("snapshot %s: %v", path, err)
+	}
+	return snap, nil
+}
+
+// writeSnapshot replaces the snapshot with [snap], which must reflect
+// every record written so far, and then starts a new, empty log.
+// A crash in between leaves records already in the snapshot in the
+// log, which are skipped when it is opened
+func (l *graphLog) writeSnapshot(snap *pb.Snapshot) error {
+	if l.err != nil {
+		return l.err
+	}
+
+	tmp := filepath.Join(l.dir, snapshotTmp)
+	if err := writeFileSync(tmp, snap); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(l.dir, snapshotFile)); err != nil {
+		return err
+	}
+	if err := syncDir(l.dir); err != nil {
+		return err
+	}
+
+	walTmp := filepath.Join(l.dir, walTmpFile)
+	if err := writeFileSync(walTmp, nil); err != nil {
+		return err
+	}
+	path := filepath.Join(l.dir, walFile)
+	if err := os.Rename(walTmp, path); err != nil {
+		return err
+	}
+
+	// From here on the old log file is gone, so the log is
+	// unusable unless the new one can be opened
+	l.wal.Close()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		l.err = err
+		return err
+	}
+	l.wal = f
+	l.size = 0
+	l.sinceSnapshot = 0
+	return syncDir(l.dir)
+}
+
+// writeFileSync writes [m] as a single frame to a new file at [path],
+// or leaves the file empty if [m] is nil, and syncs it to disk
+func writeFileSync(path string, m proto.Message) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if m != nil {
+		if _, err := writeFrame(f, m); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// syncDir syncs [dir] to disk, so that the files renamed into it stay
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// MinimumSpanningTree returns a minimum spanning forest of the requested
+// undirected graph, with a tree for each of its connected components,
+// and its total weight. The forest is stored as a new graph if requested.
+func (s *graphServiceServer) MinimumSpanningTree(ctx context.Context, req *pb.MSTRequest) (*pb.SpanningForest, error) {
+
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		return nil, err
+	}
+
+	var edges []graph.EdgeSpec
+	var total int
+	if req.Algorithm == pb.MSTAlgorithm_PRIM {
+		edges, total, err = g.Prim()
+	} else {
+		edges, total, err = g.Kruskal()
+	}
+	if err != nil {
+		return nil, graphError(req.Gid.Id, err)
+	}
+
+	res := &pb.SpanningForest{Weight: int64(total)}
+	for _, e := range edges {
+		res.Edges = append(res.Edges, &pb.Edge{
+			V1:     &pb.Vertex{Id: int32(e.From)},
+			V2:     &pb.Vertex{Id: int32(e.To)},
+			Weight: int32(e.Weight),
+		})
+	}
+
+	if req.Store {
+		forest, err := spanningGraph(g, edges)
+		if err != nil {
+			return nil, graphError(req.Gid.Id, err)
+		}
+		if res.ForestId, err = s.storeGraph(forest); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// spanningGraph returns an undirected graph with the vertices of [g],
+// placed where they are, and only [edges]
+func spanningGraph(g *graph.ItemGraph, edges []graph.EdgeSpec) (*graph.ItemGraph, error) {
+	var values []int
+	positions := make(map[int]graph.Point)
+	for _, n := range g.Nodes() {
+		values = append(values, n.Value())
+		if p, ok := n.Position(); ok {
+			positions[n.Value()] = p
+		}
+	}
+
+	res := graph.NewGraph()
+	if err := res.AddNodesAt(values, positions); err != nil {
+		return nil, err
+	}
+	if err := res.AddEdges(edges); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	graph "github.com/yc2454/Graph-Service/graph"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// errGraphNotFound is returned by a GraphStore for an ID it holds no graph for
+var errGraphNotFound = errors.New("graph not found")
+
+// storedGraph is a graph held by the server
+type storedGraph struct {
+	id      int32
+	g       *graph.ItemGraph
+	created time.Time
+}
+
+// GraphStore holds the graphs of the server by ID.
+// Implementations must be safe for concurrent use
+type GraphStore interface {
+	// Get returns the graph stored with ID=[id], or errGraphNotFound
+	Get(id int32) (*storedGraph, error)
+
+	// Put stores [sg] under its ID, replacing any graph stored there
+	Put(sg *storedGraph) error
+
+	// Delete removes the graph stored with ID=[id], or returns
+	// errGraphNotFound if there is none
+	Delete(id int32) error
+
+	// List returns at most [limit] graphs, in order of ID,
+	// starting at ID=[start]
+	List(start int32, limit int) ([]*storedGraph, error)
+
+	// NextID reserves an ID for a new graph. An ID is never returned
+	// twice, nor after a graph has been put under it, even once that
+	// graph is deleted
+	NextID() (int32, error)
+}
+
+// mutationLogger is implemented by stores that keep a record of the
+// changes to their graphs. Since the graphs are changed in place, such
+// a store is told of each mutation through Mutate, which records [rec]
+// and then calls [apply] to change the graph
+type mutationLogger interface {
+	Mutate(rec *pb.LogRecord, apply func() error) error
+}
+
+// memoryStore is a GraphStore that keeps the graphs in memory only
+type memoryStore struct {
+	graphs map[int32]*storedGraph
+
+	// The next ID to return for newly posted graph.
+	// Monotonically increase from 1
+	nextID int32
+
+	mu sync.Mutex // protects graphs and nextID
+}
+
+// Constructor of an empty memoryStore
+func newMemoryStore() *memoryStore {
+	return &memoryStore{graphs: make(map[int32]*storedGraph), nextID: 1}
+}
+
+func (m *memoryStore) Get(id int32) (*storedGraph, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sg, ok := m.graphs[id]
+	if !ok {
+		return nil, errGraphNotFound
+	}
+	return sg, nil
+}
+
+func (m *memoryStore) Put(sg *storedGraph) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.graphs[sg.id] = sg
+	if sg.id >= m.nextID {
+		m.nextID = sg.id + 1
+	}
+	return nil
+}
+
+func (m *memoryStore) Delete(id int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.graphs[id]; !ok {
+		return errGraphNotFound
+	}
+	delete(m.graphs, id)
+	return nil
+}
+
+func (m *memoryStore) List(start int32, limit int) ([]*storedGraph, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]int32, 0, len(m.graphs))
+	for id := range m.graphs {
+		if id >= start {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	page := make([]*storedGraph, len(ids))
+	for i, id := range ids {
+		page[i] = m.graphs[id]
+	}
+	return page, nil
+}
+
+func (m *memoryStore) NextID() (int32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := m.nextID
+	m.nextID++
+	return id, nil
+}
+
+// reserveIDs keeps the IDs below [next] from being returned by NextID
+func (m *memoryStore) reserveIDs(next int32) {
+	m.mu.Lock()
+	if next > m.nextID {
+		m.nextID = next
+	}
+	m.mu.Unlock()
+}
+
+// all returns every stored graph in order of ID, along with
+// the next ID to return
+func (m *memoryStore) all() ([]*storedGraph, int32) {
+	m.mu.Lock()
+	next := m.nextID
+	m.mu.Unlock()
+	page, _ := m.List(0, int(^uint(0)>>1))
+	return page, next
+}
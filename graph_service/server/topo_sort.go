@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// TopologicalSort orders the vertices of the requested directed graph
+// so that every edge goes forward, or groups them into layers that can
+// each be processed once the layers before it are done. If the graph
+// has a cycle, the error holds one as a Cycle detail.
+func (s *graphServiceServer) TopologicalSort(ctx context.Context, req *pb.TopoRequest) (*pb.TopoOrder, error) {
+
+	g, err := s.getGraph(req.Gid, "gid")
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.TopoOrder{}
+	if req.Layers {
+		layers, err := g.TopologicalLayers()
+		if err != nil {
+			return nil, graphError(req.Gid.Id, err)
+		}
+		for _, l := range layers {
+			layer := &pb.Layer{}
+			for _, v := range l {
+				layer.Vertices = append(layer.Vertices, int32(v))
+			}
+			res.Layers = append(res.Layers, layer)
+		}
+		return res, nil
+	}
+
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return nil, graphError(req.Gid.Id, err)
+	}
+	for _, v := range order {
+		res.Order = append(res.Order, int32(v))
+	}
+	return res, nil
+}
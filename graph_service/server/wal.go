@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/yc2454/Graph-Service/graph_service"
+)
+
+// The files kept in the data directory of the server
+const (
+	walFile      = "graphs.wal"
+	walTmpFile   = "graphs.wal.tmp"
+	snapshotFile = "snapshot.pb"
+	snapshotTmp  = "snapshot.tmp"
+)
+
+// Every record of the log, and the snapshot, is written as a frame:
+// the length of the payload and its CRC-32C, both little-endian
+// uint32, followed by the payload. A frame cut short by a crash, or
+// whose checksum does not match, ends the log
+const frameHeaderSize = 8
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// graphLog is the write-ahead log of the changes to the graphs of a
+// fileStore, along with the snapshots that let it be cut short.
+// It must only be used while holding the lock of the store
+type graphLog struct {
+	dir string
+	wal *os.File
+
+	// The size of the valid records in the log file
+	size int64
+
+	// Sequence number of the last record written
+	seq uint64
+
+	// The number of changes between snapshots, 0 for none,
+	// and the number of records written since the last one
+	snapshotEvery int
+	sinceSnapshot int
+
+	// Set when the log file is left in an unknown state,
+	// after which nothing more is written to it
+	err error
+}
+
+// openGraphLog opens the log kept in [dir], creating the directory if
+// needed. It returns the last snapshot along with the records written
+// after it, from which the graphs are recovered
+func openGraphLog(dir string, snapshotEvery int) (*graphLog, *pb.Snapshot, []*pb.LogRecord, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, nil, nil, err
+	}
+
+	snap, err := readSnapshot(filepath.Join(dir, snapshotFile))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	path := filepath.Join(dir, walFile)
+	recs, size, err := readLog(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// The records up to the snapshot are left over from a crash
+	// before the log was reset, and are already in the snapshot
+	seq := snap.Seq
+	var after []*pb.LogRecord
+	for _, rec := range recs {
+		if rec.Seq <= snap.Seq {
+			continue
+		}
+		if rec.Seq != seq+1 {
+			return nil, nil, nil, fmt.Errorf("log record %d follows record %d", rec.Seq, seq)
+		}
+		seq = rec.Seq
+		after = append(after, rec)
+	}
+
+	// Drop a record torn by a crash, so that new ones follow
+	// the last valid record
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		return nil, nil, nil, err
+	}
+	if _, err := f.Seek(size, io.SeekStart); err != nil {
+		f.Close()
+		return nil, nil, nil, err
+	}
+
+	l := &graphLog{
+		dir:           dir,
+		wal:           f,
+		size:          size,
+		seq:           seq,
+		snapshotEvery: snapshotEvery,
+		sinceSnapshot: len(after),
+	}
+	return l, snap, after, nil
+}
+
+// append writes [rec] to the log with the next sequence number,
+// and syncs it to disk
+func (l *graphLog) append(rec *pb.LogRecord) error {
+	if l.err != nil {
+		return l.err
+	}
+
+	rec.Seq = l.seq + 1
+	n, err := writeFrame(l.wal, rec)
+	if err == nil {
+		err = l.wal.Sync()
+	}
+	if err != nil {
+		// Cut off what was written of the record, so that
+		// later records are not lost behind it
+		if terr := l.wal.Truncate(l.size); terr != nil {
+			l.err = terr
+		} else if _, serr := l.wal.Seek(l.size, io.SeekStart); serr != nil {
+			l.err = serr
+		}
+		return err
+	}
+
+	l.size += int64(n)
+	l.seq = rec.Seq
+	l.sinceSnapshot++
+	return nil
+}
+
+// close closes the log file
+func (l *graphLog) close() error {
+	return l.wal.Close()
+}
+
+// readLog reads the records of the log file at [path], stopping at
+// the first torn or corrupted one. It also returns the size of the
+// records read. A missing file is an empty log
+func readLog(path string) ([]*pb.LogRecord, int64, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var recs []*pb.LogRecord
+	var size int64
+	for {
+		payload, n := readFrame(data[size:])
+		if n == 0 {
+			break
+		}
+		rec := new(pb.LogRecord)
+		if err := proto.Unmarshal(payload, rec); err != nil {
+			return nil, 0, fmt.Errorf("log record at offset %d: %v", size, err)
+		}
+		recs = append(recs, rec)
+		size += int64(n)
+	}
+	return recs, size, nil
+}
+
+// writeFrame writes [m] to [w] as a single frame, and returns
+// the number of bytes written
+func writeFrame(w io.Writer, m proto.Message) (int, error) {
+	payload, err := proto.Marshal(m)
+	if err != nil {
+		return 0, err
+	}
+	buf := make([]byte, frameHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.Checksum(payload, crcTable))
+	copy(buf[frameHeaderSize:], payload)
+	return w.Write(buf)
+}
+
+// readFrame returns the payload of the frame at the start of [data]
+// and the size of the frame, or a size of 0 if there is no valid
+// frame there
+func readFrame(data []byte) ([]byte, int) {
+	if len(data) < frameHeaderSize {
+		return nil, 0
+	}
+	length := binary.LittleEndian.Uint32(data[0:4])
+	sum := binary.LittleEndian.Uint32(data[4:8])
+	if uint64(len(data)-frameHeaderSize) < uint64(length) {
+		return nil, 0
+	}
+	payload := data[frameHeaderSize : frameHeaderSize+int(length)]
+	if crc32.Checksum(payload, crcTable) != sum {
+		return nil, 0
+	}
+	return payload, frameHeaderSize + int(length)
+}
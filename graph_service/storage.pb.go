@@ -0,0 +1,468 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.4
+// source: storage.proto
+
+package graphservice
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A graph as stored by the server
+type StoredGraph struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gid       *GraphID               `protobuf:"bytes,1,opt,name=gid,proto3" json:"gid,omitempty"`
+	Graph     *Graph                 `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *StoredGraph) Reset() {
+	*x = StoredGraph{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoredGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoredGraph) ProtoMessage() {}
+
+func (x *StoredGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoredGraph.ProtoReflect.Descriptor instead.
+func (*StoredGraph) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StoredGraph) GetGid() *GraphID {
+	if x != nil {
+		return x.Gid
+	}
+	return nil
+}
+
+func (x *StoredGraph) GetGraph() *Graph {
+	if x != nil {
+		return x.Graph
+	}
+	return nil
+}
+
+func (x *StoredGraph) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// A change to the stored graphs, as written to the write-ahead log
+type LogRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Position of the record in the log, counting from 1
+	Seq uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Types that are assignable to Op:
+	//	*LogRecord_PostGraph
+	//	*LogRecord_DeleteGraph
+	//	*LogRecord_AddVertices
+	//	*LogRecord_RemoveVertices
+	//	*LogRecord_AddEdges
+	//	*LogRecord_RemoveEdges
+	Op isLogRecord_Op `protobuf_oneof:"op"`
+}
+
+func (x *LogRecord) Reset() {
+	*x = LogRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogRecord) ProtoMessage() {}
+
+func (x *LogRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogRecord.ProtoReflect.Descriptor instead.
+func (*LogRecord) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LogRecord) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (m *LogRecord) GetOp() isLogRecord_Op {
+	if m != nil {
+		return m.Op
+	}
+	return nil
+}
+
+func (x *LogRecord) GetPostGraph() *StoredGraph {
+	if x, ok := x.GetOp().(*LogRecord_PostGraph); ok {
+		return x.PostGraph
+	}
+	return nil
+}
+
+func (x *LogRecord) GetDeleteGraph() *GraphID {
+	if x, ok := x.GetOp().(*LogRecord_DeleteGraph); ok {
+		return x.DeleteGraph
+	}
+	return nil
+}
+
+func (x *LogRecord) GetAddVertices() *VerticesRequest {
+	if x, ok := x.GetOp().(*LogRecord_AddVertices); ok {
+		return x.AddVertices
+	}
+	return nil
+}
+
+func (x *LogRecord) GetRemoveVertices() *VerticesRequest {
+	if x, ok := x.GetOp().(*LogRecord_RemoveVertices); ok {
+		return x.RemoveVertices
+	}
+	return nil
+}
+
+func (x *LogRecord) GetAddEdges() *EdgesRequest {
+	if x, ok := x.GetOp().(*LogRecord_AddEdges); ok {
+		return x.AddEdges
+	}
+	return nil
+}
+
+func (x *LogRecord) GetRemoveEdges() *EdgesRequest {
+	if x, ok := x.GetOp().(*LogRecord_RemoveEdges); ok {
+		return x.RemoveEdges
+	}
+	return nil
+}
+
+type isLogRecord_Op interface {
+	isLogRecord_Op()
+}
+
+type LogRecord_PostGraph struct {
+	PostGraph *StoredGraph `protobuf:"bytes,2,opt,name=post_graph,json=postGraph,proto3,oneof"`
+}
+
+type LogRecord_DeleteGraph struct {
+	DeleteGraph *GraphID `protobuf:"bytes,3,opt,name=delete_graph,json=deleteGraph,proto3,oneof"`
+}
+
+type LogRecord_AddVertices struct {
+	AddVertices *VerticesRequest `protobuf:"bytes,4,opt,name=add_vertices,json=addVertices,proto3,oneof"`
+}
+
+type LogRecord_RemoveVertices struct {
+	RemoveVertices *VerticesRequest `protobuf:"bytes,5,opt,name=remove_vertices,json=removeVertices,proto3,oneof"`
+}
+
+type LogRecord_AddEdges struct {
+	AddEdges *EdgesRequest `protobuf:"bytes,6,opt,name=add_edges,json=addEdges,proto3,oneof"`
+}
+
+type LogRecord_RemoveEdges struct {
+	RemoveEdges *EdgesRequest `protobuf:"bytes,7,opt,name=remove_edges,json=removeEdges,proto3,oneof"`
+}
+
+func (*LogRecord_PostGraph) isLogRecord_Op() {}
+
+func (*LogRecord_DeleteGraph) isLogRecord_Op() {}
+
+func (*LogRecord_AddVertices) isLogRecord_Op() {}
+
+func (*LogRecord_RemoveVertices) isLogRecord_Op() {}
+
+func (*LogRecord_AddEdges) isLogRecord_Op() {}
+
+func (*LogRecord_RemoveEdges) isLogRecord_Op() {}
+
+// The stored graphs as of one record of the log
+type Snapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sequence number of the last record reflected in the snapshot
+	Seq uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// The ID of the next posted graph
+	NextId int32          `protobuf:"varint,2,opt,name=next_id,json=nextId,proto3" json:"next_id,omitempty"`
+	Graphs []*StoredGraph `protobuf:"bytes,3,rep,name=graphs,proto3" json:"graphs,omitempty"`
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_storage_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Snapshot) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Snapshot) GetNextId() int32 {
+	if x != nil {
+		return x.NextId
+	}
+	return 0
+}
+
+func (x *Snapshot) GetGraphs() []*StoredGraph {
+	if x != nil {
+		return x.Graphs
+	}
+	return nil
+}
+
+var File_storage_proto protoreflect.FileDescriptor
+
+var file_storage_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0c, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9c, 0x01, 0x0a, 0x0b,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x27, 0x0a, 0x03, 0x67,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x49, 0x44, 0x52,
+	0x03, 0x67, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12,
+	0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xa5, 0x03, 0x0a, 0x09, 0x4c,
+	0x6f, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x3a, 0x0a, 0x0a, 0x70, 0x6f,
+	0x73, 0x74, 0x5f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x64, 0x47, 0x72, 0x61, 0x70, 0x68, 0x48, 0x00, 0x52, 0x09, 0x70, 0x6f, 0x73,
+	0x74, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12, 0x3a, 0x0a, 0x0c, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x5f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x72, 0x61, 0x70,
+	0x68, 0x49, 0x44, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x12, 0x42, 0x0a, 0x0c, 0x61, 0x64, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x64, 0x64, 0x56, 0x65,
+	0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x12, 0x48, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x5f, 0x76, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x56,
+	0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x56, 0x65, 0x72, 0x74, 0x69, 0x63, 0x65, 0x73,
+	0x12, 0x39, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x5f, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x08, 0x61, 0x64, 0x64, 0x45, 0x64, 0x67, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x0c, 0x72,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x45, 0x64, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x45, 0x64, 0x67, 0x65, 0x73, 0x42, 0x04, 0x0a, 0x02,
+	0x6f, 0x70, 0x22, 0x68, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71,
+	0x12, 0x17, 0x0a, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x6e, 0x65, 0x78, 0x74, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x52, 0x06, 0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x42, 0x2e, 0x5a, 0x2c,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x79, 0x63, 0x32, 0x34, 0x35,
+	0x34, 0x2f, 0x47, 0x72, 0x61, 0x70, 0x68, 0x2d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f,
+	0x67, 0x72, 0x61, 0x70, 0x68, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_storage_proto_rawDescOnce sync.Once
+	file_storage_proto_rawDescData = file_storage_proto_rawDesc
+)
+
+func file_storage_proto_rawDescGZIP() []byte {
+	file_storage_proto_rawDescOnce.Do(func() {
+		file_storage_proto_rawDescData = protoimpl.X.CompressGZIP(file_storage_proto_rawDescData)
+	})
+	return file_storage_proto_rawDescData
+}
+
+var file_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_storage_proto_goTypes = []interface{}{
+	(*StoredGraph)(nil),           // 0: graphservice.StoredGraph
+	(*LogRecord)(nil),             // 1: graphservice.LogRecord
+	(*Snapshot)(nil),              // 2: graphservice.Snapshot
+	(*GraphID)(nil),               // 3: graphservice.GraphID
+	(*Graph)(nil),                 // 4: graphservice.Graph
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*VerticesRequest)(nil),       // 6: graphservice.VerticesRequest
+	(*EdgesRequest)(nil),          // 7: graphservice.EdgesRequest
+}
+var file_storage_proto_depIdxs = []int32{
+	3,  // 0: graphservice.StoredGraph.gid:type_name -> graphservice.GraphID
+	4,  // 1: graphservice.StoredGraph.graph:type_name -> graphservice.Graph
+	5,  // 2: graphservice.StoredGraph.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: graphservice.LogRecord.post_graph:type_name -> graphservice.StoredGraph
+	3,  // 4: graphservice.LogRecord.delete_graph:type_name -> graphservice.GraphID
+	6,  // 5: graphservice.LogRecord.add_vertices:type_name -> graphservice.VerticesRequest
+	6,  // 6: graphservice.LogRecord.remove_vertices:type_name -> graphservice.VerticesRequest
+	7,  // 7: graphservice.LogRecord.add_edges:type_name -> graphservice.EdgesRequest
+	7,  // 8: graphservice.LogRecord.remove_edges:type_name -> graphservice.EdgesRequest
+	0,  // 9: graphservice.Snapshot.graphs:type_name -> graphservice.StoredGraph
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_storage_proto_init() }
+func file_storage_proto_init() {
+	if File_storage_proto != nil {
+		return
+	}
+	file_graph_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_storage_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StoredGraph); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storage_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_storage_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_storage_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*LogRecord_PostGraph)(nil),
+		(*LogRecord_DeleteGraph)(nil),
+		(*LogRecord_AddVertices)(nil),
+		(*LogRecord_RemoveVertices)(nil),
+		(*LogRecord_AddEdges)(nil),
+		(*LogRecord_RemoveEdges)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_storage_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_storage_proto_goTypes,
+		DependencyIndexes: file_storage_proto_depIdxs,
+		MessageInfos:      file_storage_proto_msgTypes,
+	}.Build()
+	File_storage_proto = out.File
+	file_storage_proto_rawDesc = nil
+	file_storage_proto_goTypes = nil
+	file_storage_proto_depIdxs = nil
+}